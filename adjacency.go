@@ -0,0 +1,186 @@
+package strgo
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+)
+
+// Direction is the side of a char an AdjacencyRule looks at.
+type Direction int
+
+const (
+	// DirectionNext looks at the next char.
+	DirectionNext Direction = iota + 1
+	// DirectionPrevious looks at the previous char.
+	DirectionPrevious
+	// DirectionBoth looks at the previous and the next char.
+	DirectionBoth
+)
+
+// AdjacencyRule is a rule on the neighbours of the Chars, on the side of its
+// Direction: they must be in Neighbors, so a char can't be at the edge of the
+// string on that side, or with Not, they must not be in Neighbors.
+type AdjacencyRule struct {
+	Chars     CharSet
+	Neighbors CharSet
+	Direction Direction
+	Not       bool
+}
+
+// adjacencyRule is a compiled rule pair of the MustBe*By and MustNotBe*By
+// fields or an AdjacencyRule: the chars, the neighbours they need (or must not
+// have), and the sides of the char it looks at. index is the index of the rule
+// in its condition field.
+type adjacencyRule struct {
+	rule      Rule
+	index     int
+	chars     CharSet
+	neighbors CharSet
+	prev      bool
+	next      bool
+	not       bool
+}
+
+// expected returns the neighbours of the rule as they're written in its error.
+func (r *adjacencyRule) expected() string {
+	return string(r.neighbors.Bytes())
+}
+
+// fails tells if the rule is violated by the neighbour n on one of its sides,
+// n is -1 for the edge of the string or a non-ASCII char.
+func (r *adjacencyRule) fails(n int) bool {
+	if r.not {
+		return n >= 0 && r.neighbors.Contains(byte(n))
+	}
+
+	return n < 0 || !r.neighbors.Contains(byte(n))
+}
+
+// adjacent are the neighbours a char allows on each side, merged from every
+// rule on the char, so a char is checked with a lookup however many rules it
+// has. openPrev and openNext tell if the edge of the string or a non-ASCII char
+// are allowed, which is the case when only MustNotBe*By rules look at the side.
+type adjacent struct {
+	prev      CharSet
+	next      CharSet
+	openPrev  bool
+	openNext  bool
+	checkNext bool
+}
+
+// compileAdjacency appends to rules the rule pairs of the condition in the
+// order of the fields, then its Adjacency rules. A pair without chars or
+// without neighbours is skipped.
+func compileAdjacency(cond *ByteCondition, rules []adjacencyRule) ([]adjacencyRule, error) {
+	for _, field := range [...]struct {
+		rule  Rule
+		pairs [][2]CharSet
+		prev  bool
+		next  bool
+		not   bool
+	}{
+		{rule: RuleMustBeFollowedBy, pairs: cond.MustBeFollowedBy, next: true},
+		{rule: RuleMustBePrecededBy, pairs: cond.MustBePrecededBy, prev: true},
+		{rule: RuleMustBeSurroundedBy, pairs: cond.MustBeSurroundedBy, prev: true, next: true},
+		{rule: RuleMustNotBeFollowedBy, pairs: cond.MustNotBeFollowedBy, next: true, not: true},
+		{rule: RuleMustNotBePrecededBy, pairs: cond.MustNotBePrecededBy, prev: true, not: true},
+	} {
+		for i, pair := range field.pairs {
+			if pair[0].IsEmpty() || pair[1].IsEmpty() {
+				continue
+			}
+			rules = append(rules, adjacencyRule{
+				rule:      field.rule,
+				index:     i,
+				chars:     pair[0].members(),
+				neighbors: pair[1].kept(),
+				prev:      field.prev,
+				next:      field.next,
+				not:       field.not,
+			})
+		}
+	}
+	for i, r := range cond.Adjacency {
+		if r.Direction < DirectionNext || r.Direction > DirectionBoth {
+			return nil, errors.New("the direction of the adjacency rule: " + strconv.Itoa(i) + ", is invalid")
+		}
+		rules = append(rules, adjacencyRule{
+			rule:      RuleAdjacency,
+			index:     i,
+			chars:     r.Chars.members(),
+			neighbors: r.Neighbors.kept(),
+			prev:      r.Direction != DirectionNext,
+			next:      r.Direction != DirectionPrevious,
+			not:       r.Not,
+		})
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	return rules, nil
+}
+
+// compileAdjacent appends to entries the merged rules of every char, the chars
+// with the same merged rules share their entry, and sets the index of the
+// entry of each char. The chars without a rule aren't indexed.
+func compileAdjacent(rules []adjacencyRule, entries []adjacent, index *[asciiMaxDec + 1]uint8) []adjacent {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var chars CharSet
+	for k := range rules {
+		chars = chars.Union(rules[k].chars)
+	}
+	for h, word := range chars.bits {
+		for ; word != 0; word &= word - 1 {
+			c := byte(h<<6 | bits.TrailingZeros64(word))
+			a := adjacent{prev: asciiSet, next: asciiSet, openPrev: true, openNext: true}
+			for k := range rules {
+				r := &rules[k]
+				if !r.chars.Contains(c) {
+					continue
+				}
+				if r.prev {
+					a.prev, a.openPrev = r.merge(a.prev, a.openPrev)
+				}
+				if r.next {
+					a.next, a.openNext = r.merge(a.next, a.openNext)
+					a.checkNext = true
+				}
+			}
+			i := 0
+			for i < len(entries) && entries[i] != a {
+				i++
+			}
+			if i == len(entries) {
+				entries = append(entries, a)
+			}
+			index[c] = uint8(i)
+		}
+	}
+
+	return entries
+}
+
+// merge returns the neighbours of a side once the rule is applied.
+func (r *adjacencyRule) merge(allowed CharSet, open bool) (CharSet, bool) {
+	if r.not {
+		return allowed.Minus(r.neighbors), open
+	}
+
+	return allowed.Intersect(r.neighbors), false
+}
+
+// allows tells if the neighbour n is allowed by the side, n is -1 for the edge
+// of the string or a non-ASCII char.
+func allows(allowed CharSet, open bool, n int) bool {
+	if n < 0 {
+		return open
+	}
+
+	return allowed.Contains(byte(n))
+}
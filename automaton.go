@@ -0,0 +1,100 @@
+package strgo
+
+// automaton is an Aho-Corasick automaton over a set of words. The goto and
+// failure functions are merged into a single DFA, so scanning a text costs one
+// table lookup per byte no matter how many words are searched.
+//
+// Bytes are compressed into classes: every byte that doesn't appear in any word
+// shares class 0, which keeps the transition table small for big word lists.
+type automaton struct {
+	words  []string
+	class  [256]int32
+	stride int
+	delta  []int32
+	out    []int32
+	link   []int32
+	word   []int32
+}
+
+// newAutomaton builds the automaton. The words must be unique and non-empty,
+// the id of a word is its index in the slice.
+func newAutomaton(words []string) *automaton {
+	a := &automaton{words: words}
+
+	classes := int32(1)
+	for _, w := range words {
+		for i := 0; i < len(w); i++ {
+			if a.class[w[i]] == 0 {
+				a.class[w[i]] = classes
+				classes++
+			}
+		}
+	}
+	a.stride = int(classes)
+
+	// trie
+	var (
+		trie = [][]int32{make([]int32, a.stride)}
+		word = []int32{-1}
+	)
+	for id, w := range words {
+		s := int32(0)
+		for i := 0; i < len(w); i++ {
+			c := a.class[w[i]]
+			if trie[s][c] == 0 {
+				trie = append(trie, make([]int32, a.stride))
+				word = append(word, -1)
+				trie[s][c] = int32(len(trie) - 1)
+			}
+			s = trie[s][c]
+		}
+		word[s] = int32(id)
+	}
+
+	// breadth first walk to resolve the failure links into the dfa
+	states := len(trie)
+	a.delta = make([]int32, states*a.stride)
+	a.out = make([]int32, states)
+	a.link = make([]int32, states)
+	a.word = word
+	fail := make([]int32, states)
+	queue := make([]int32, 0, states)
+
+	a.out[0] = -1
+	a.link[0] = -1
+	for c := 0; c < a.stride; c++ {
+		if t := trie[0][c]; t != 0 {
+			a.delta[c] = t
+			queue = append(queue, t)
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		f := fail[s]
+		a.link[s] = a.out[f]
+		if a.word[s] >= 0 {
+			a.out[s] = s
+		} else {
+			a.out[s] = a.link[s]
+		}
+		row := int(s) * a.stride
+		for c := 0; c < a.stride; c++ {
+			fc := a.delta[int(f)*a.stride+c]
+			if t := trie[s][c]; t != 0 {
+				a.delta[row+c] = t
+				fail[t] = fc
+				queue = append(queue, t)
+			} else {
+				a.delta[row+c] = fc
+			}
+		}
+	}
+
+	return a
+}
+
+// step moves the automaton from state s by the byte b.
+func (a *automaton) step(s int32, b byte) int32 {
+	return a.delta[int(s)*a.stride+int(a.class[b])]
+}
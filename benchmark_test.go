@@ -4,121 +4,209 @@ import (
 	"github.com/dalikewara/strgo"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 )
 
 func BenchmarkByte(b *testing.B) {
-	bt := []byte("akdjfnafjweifwef..,./'91840jsafnkafkabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321awjdbjwfjhabfjwqbfjebfawkhfiuqwuqwqmlksmANXMASNBFIQWHFDIQWDQWIJODFWQHFIWQHEU12Y431U4IU4O12KJEN2JEHIO2UEJSBasbfkjaenfkqnefkehmdqwdiwqbrwqbrjwqkdfwqfjwqnfqehriquhrqwnrwoqrwoqdqwohiwoqjewoqihewqu")
-	strgo.Byte("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliqua.Utenimadminimveniam.quisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequat.Duisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariatur.Excepteursintoccaecatcupidatatnonproident.suntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
-		OnlyContains:                append(bt, strgo.SpecialCharsByte...),
-		OnlyContainsPrefix:          bt,
-		OnlyContainsSuffix:          bt,
-		MustContains:                strgo.AlphanumericByte,
-		MustContainsOnce:            []byte{'+'},
-		MustNotContains:             strgo.BracketsByte,
-		MustNotContainsPrefix:       strgo.SpecialCharsByte,
-		MustNotContainsSuffix:       strgo.SpecialCharsByte,
-		MayContainsOnce:             []byte{'+'},
-		MustBeFollowedBy:            [2][]byte{strgo.SpecialCharsByte, strgo.CharsByte},
-		AtLeastHaveUpperLetterCount: 2,
-		AtLeastHaveLowerLetterCount: 2,
-		AtLeastHaveNumberCount:      2,
-		AtLeastHaveSpecialCharCount: 2,
-	})
+	bt := strgo.CharSetOf([]byte("akdjfnafjweifwef..,./'91840jsafnkafkabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321awjdbjwfjhabfjwqbfjebfawkhfiuqwuqwqmlksmANXMASNBFIQWHFDIQWDQWIJODFWQHFIWQHEU12Y431U4IU4O12KJEN2JEHIO2UEJSBasbfkjaenfkqnefkehmdqwdiwqbrwqbrjwqkdfwqfjwqnfqehriquhrqwnrwoqrwoqdqwohiwoqjewoqihewqu")...)
+	for i := 0; i < b.N; i++ {
+		strgo.Byte("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliqua.Utenimadminimveniam.quisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequat.Duisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariatur.Excepteursintoccaecatcupidatatnonproident.suntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
+			OnlyContains:                bt.Union(strgo.SpecialCharsSet),
+			OnlyContainsPrefix:          bt,
+			OnlyContainsSuffix:          bt,
+			MustContains:                strgo.AlphanumericSet,
+			MustContainsOnce:            strgo.CharSetOf('+'),
+			MustNotContains:             strgo.BracketsSet,
+			MustNotContainsPrefix:       strgo.SpecialCharsSet,
+			MustNotContainsSuffix:       strgo.SpecialCharsSet,
+			MayContainsOnce:             strgo.CharSetOf('+'),
+			MustBeSurroundedBy:          [][2]strgo.CharSet{{strgo.SpecialCharsSet, strgo.CharsSet}},
+			AtLeastHaveUpperLetterCount: 2,
+			AtLeastHaveLowerLetterCount: 2,
+			AtLeastHaveNumberCount:      2,
+			AtLeastHaveSpecialCharCount: 2,
+		})
+	}
 }
 
 func BenchmarkByteUsername(b *testing.B) {
-	strgo.Byte("john_doe.123", &strgo.ByteCondition{
-		OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.'}...),
-		MustBeFollowedBy: [2][]byte{{'_', '.'}, strgo.AlphanumericByte},
-		MayContainsOnce:  []byte{'_', '.'},
-	})
+	for i := 0; i < b.N; i++ {
+		strgo.Byte("john_doe.123", &strgo.ByteCondition{
+			OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+			MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+			MayContainsOnce:    strgo.CharSetOf('_', '.'),
+		})
+	}
 }
 
 func BenchmarkByteUsernameLongText(b *testing.B) {
-	strgo.Byte("Loremipsumdolorsitametconse_ct.eturadipiscingelitabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
-		OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.'}...),
-		MustBeFollowedBy: [2][]byte{{'_', '.'}, strgo.AlphanumericByte},
-		MayContainsOnce:  []byte{'_', '.'},
-	})
+	for i := 0; i < b.N; i++ {
+		strgo.Byte("Loremipsumdolorsitametconse_ct.eturadipiscingelitabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
+			OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+			MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+			MayContainsOnce:    strgo.CharSetOf('_', '.'),
+		})
+	}
 }
 
 func BenchmarkByteEmail(b *testing.B) {
-	strgo.Byte("john+doe123@email", &strgo.ByteCondition{
-		OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.', '@', '-', '+'}...),
-		MustBeFollowedBy: [2][]byte{{'_', '.', '@', '-', '+'}, strgo.AlphanumericByte},
-		MustContainsOnce: []byte{'@'},
-	})
+	for i := 0; i < b.N; i++ {
+		strgo.Byte("john+doe123@email", &strgo.ByteCondition{
+			OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.', '@', '-', '+')),
+			MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.', '@', '-', '+'), strgo.AlphanumericSet}},
+			MustContainsOnce:   strgo.CharSetOf('@'),
+		})
+	}
 }
 
 func BenchmarkByteEmailLongText(b *testing.B) {
-	strgo.Byte("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
-		OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.', '@', '-', '+'}...),
-		MustBeFollowedBy: [2][]byte{{'_', '.', '@', '-', '+'}, strgo.AlphanumericByte},
-		MustContainsOnce: []byte{'@'},
-	})
+	for i := 0; i < b.N; i++ {
+		strgo.Byte("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
+			OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.', '@', '-', '+')),
+			MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.', '@', '-', '+'), strgo.AlphanumericSet}},
+			MustContainsOnce:   strgo.CharSetOf('@'),
+		})
+	}
 }
 
 func BenchmarkBytePassword(b *testing.B) {
-	strgo.Byte("john_DOe.123", &strgo.ByteCondition{
-		OnlyContains:                strgo.CharsByte,
-		AtLeastHaveUpperLetterCount: 2,
-		AtLeastHaveLowerLetterCount: 2,
-		AtLeastHaveNumberCount:      2,
-		AtLeastHaveSpecialCharCount: 2,
-	})
+	for i := 0; i < b.N; i++ {
+		strgo.Byte("john_DOe.123", &strgo.ByteCondition{
+			OnlyContains:                strgo.CharsSet,
+			AtLeastHaveUpperLetterCount: 2,
+			AtLeastHaveLowerLetterCount: 2,
+			AtLeastHaveNumberCount:      2,
+			AtLeastHaveSpecialCharCount: 2,
+		})
+	}
 }
 
 func BenchmarkBytePasswordLongText(b *testing.B) {
-	strgo.Byte("Loremipsumdolorsitametconse_ct.eturadipiscingelitabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
-		OnlyContains:                strgo.CharsByte,
-		AtLeastHaveUpperLetterCount: 2,
-		AtLeastHaveLowerLetterCount: 2,
-		AtLeastHaveNumberCount:      2,
-		AtLeastHaveSpecialCharCount: 2,
-	})
+	for i := 0; i < b.N; i++ {
+		strgo.Byte("Loremipsumdolorsitametconse_ct.eturadipiscingelitabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.ByteCondition{
+			OnlyContains:                strgo.CharsSet,
+			AtLeastHaveUpperLetterCount: 2,
+			AtLeastHaveLowerLetterCount: 2,
+			AtLeastHaveNumberCount:      2,
+			AtLeastHaveSpecialCharCount: 2,
+		})
+	}
+}
+
+const benchmarkLongText = "Loremipsumdolorsitametconse_ct.eturadipiscingelitabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum"
+
+const benchmarkEmailLongText = "Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum"
+
+var benchmarkUsernameCondition = &strgo.ByteCondition{
+	OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+	MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+	MayContainsOnce:    strgo.CharSetOf('_', '.'),
+}
+
+var benchmarkEmailCondition = &strgo.ByteCondition{
+	OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.', '@', '-', '+')),
+	MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.', '@', '-', '+'), strgo.AlphanumericSet}},
+	MustContainsOnce:   strgo.CharSetOf('@'),
+}
+
+var benchmarkPasswordCondition = &strgo.ByteCondition{
+	OnlyContains:                strgo.CharsSet,
+	AtLeastHaveUpperLetterCount: 2,
+	AtLeastHaveLowerLetterCount: 2,
+	AtLeastHaveNumberCount:      2,
+	AtLeastHaveSpecialCharCount: 2,
+}
+
+func benchmarkByteValidator(b *testing.B, cond *strgo.ByteCondition, text string) {
+	v, err := strgo.CompileByte(cond)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = v.Validate(text)
+	}
+}
+
+func BenchmarkByteValidatorUsername(b *testing.B) {
+	benchmarkByteValidator(b, benchmarkUsernameCondition, "john_doe.123")
+}
+
+func BenchmarkByteValidatorUsernameLongText(b *testing.B) {
+	benchmarkByteValidator(b, benchmarkUsernameCondition, benchmarkLongText)
+}
+
+func BenchmarkByteValidatorEmail(b *testing.B) {
+	benchmarkByteValidator(b, benchmarkEmailCondition, "john+doe123@email")
+}
+
+func BenchmarkByteValidatorEmailLongText(b *testing.B) {
+	benchmarkByteValidator(b, benchmarkEmailCondition, benchmarkEmailLongText)
+}
+
+func BenchmarkByteValidatorPassword(b *testing.B) {
+	benchmarkByteValidator(b, benchmarkPasswordCondition, "john_DOe.123")
+}
+
+func BenchmarkByteValidatorPasswordLongText(b *testing.B) {
+	benchmarkByteValidator(b, benchmarkPasswordCondition, benchmarkLongText)
 }
 
 func BenchmarkString(b *testing.B) {
 	w := strings.Split("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliqua.Utenimadminimveniam.quisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequat.Duisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariatur.Excepteursintoccaecatcupidatatnonproident.suntinculpaquiofficiadeseruntmollitanimidestlaborum", "@")
-	strgo.String("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliqua.Utenimadminimveniam.quisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequat.Duisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariatur.Excepteursintoccaecatcupidatatnonproident.suntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.StringCondition{
-		OnlyContainsPrefixWord:    w,
-		OnlyContainsSuffixWord:    w,
-		MustContainsWord:          w,
-		MustContainsWordOnce:      []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
-		MustNotContainsWord:       []string{"+olo2", "met.con2", "lit.abcde2", "4321seddoei2"},
-		MustNotContainsPrefixWord: []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
-		MustNotContainsSuffixWord: []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
-		MayContainsWordOnce:       []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
-	})
+	for i := 0; i < b.N; i++ {
+		strgo.String("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliqua.Utenimadminimveniam.quisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequat.Duisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariatur.Excepteursintoccaecatcupidatatnonproident.suntinculpaquiofficiadeseruntmollitanimidestlaborum", &strgo.StringCondition{
+			OnlyContainsPrefixWord:    w,
+			OnlyContainsSuffixWord:    w,
+			MustContainsWord:          w,
+			MustContainsWordOnce:      []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
+			MustNotContainsWord:       []string{"+olo2", "met.con2", "lit.abcde2", "4321seddoei2"},
+			MustNotContainsPrefixWord: []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
+			MustNotContainsSuffixWord: []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
+			MayContainsWordOnce:       []string{"+olo", "met.con", "lit.abcde", "4321seddoei"},
+		})
+	}
 }
 
 func BenchmarkRegexUsername(b *testing.B) {
 	regex, _ := regexp.Compile(`^[a-z0-9._%+\-@]+$`)
-	_ = regex.MatchString("john_doe.123")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = regex.MatchString("john_doe.123")
+	}
 }
 
 func BenchmarkRegexUsernameLongtext(b *testing.B) {
 	regex, _ := regexp.Compile(`^[a-z0-9._%+\-@]+$`)
-	_ = regex.MatchString("Loremipsumdolorsitametconse_ct.eturadipiscingelitabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = regex.MatchString("Loremipsumdolorsitametconse_ct.eturadipiscingelitabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum")
+	}
 }
 
 func BenchmarkRegexEmail(b *testing.B) {
 	regex, _ := regexp.Compile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]+$`)
-	_ = regex.MatchString("john+doe123@email")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = regex.MatchString("john+doe123@email")
+	}
 }
 
 func BenchmarkRegexEmailLongText(b *testing.B) {
 	regex, _ := regexp.Compile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]+$`)
-	_ = regex.MatchString("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = regex.MatchString("Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliquaUtenimadminimveniamquisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequatDuisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariaturExcepteursintoccaecatcupidatatnonproidentsuntinculpaquiofficiadeseruntmollitanimidestlaborum")
+	}
 }
 
 func TestElapsedTime(t *testing.T) {
 	text := "Loremipsumd+olorsitamet.consectetur@adipiscingelit.abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321seddoeiusmodtemporincididuntutlaboreetdoloremagnaaliqua.Utenimadminimveniam.quisnostrudexercitationullamcolaborisnisiutaliquipexeacommodoconsequat.Duisauteiruredolorinreprehenderitinvoluptatevelitessecillumdoloreeufugiatnullapariatur.Excepteursintoccaecatcupidatatnonproident.suntinculpaquiofficiadeseruntmollitanimidestlaborum"
 	text2 := "akdjfnafjweifwef..,./'91840jsafnkafkabcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0987654321awjdbjwfjhabfjwqbfjebfawkhfiuqwuqwqmlksmANXMASNBFIQWHFDIQWDQWIJODFWQHFIWQHEU12Y431U4IU4O12KJEN2JEHIO2UEJSBasbfkjaenfkqnefkehmdqwdiwqbrwqbrjwqkdfwqfjwqnfqehriquhrqwnrwoqrwoqdqwohiwoqjewoqihewqu"
-	text2Byte := []byte(text2)
+	text2Byte := strgo.CharSetOf([]byte(text2)...)
 	words := strings.Split(text, "a")
 	r1 := `^[a-z0-9._%+\-@]+$`
 	r2 := `^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]+$`
@@ -130,16 +218,16 @@ func TestElapsedTime(t *testing.T) {
 
 	start := time.Now()
 	err := strgo.Byte(text, &strgo.ByteCondition{
-		OnlyContains:                append(text2Byte, strgo.SpecialCharsByte...),
+		OnlyContains:                text2Byte.Union(strgo.SpecialCharsSet),
 		OnlyContainsPrefix:          text2Byte,
 		OnlyContainsSuffix:          text2Byte,
-		MustContains:                strgo.AlphanumericByte,
-		MustContainsOnce:            []byte{'+'},
-		MustNotContains:             strgo.BracketsByte,
-		MustNotContainsPrefix:       strgo.SpecialCharsByte,
-		MustNotContainsSuffix:       strgo.SpecialCharsByte,
-		MayContainsOnce:             []byte{'+'},
-		MustBeFollowedBy:            [2][]byte{strgo.SpecialCharsByte, strgo.CharsByte},
+		MustContains:                strgo.AlphanumericSet,
+		MustContainsOnce:            strgo.CharSetOf('+'),
+		MustNotContains:             strgo.BracketsSet,
+		MustNotContainsPrefix:       strgo.SpecialCharsSet,
+		MustNotContainsSuffix:       strgo.SpecialCharsSet,
+		MayContainsOnce:             strgo.CharSetOf('+'),
+		MustBeSurroundedBy:          [][2]strgo.CharSet{{strgo.SpecialCharsSet, strgo.CharsSet}},
 		AtLeastHaveUpperLetterCount: 2,
 		AtLeastHaveLowerLetterCount: 2,
 		AtLeastHaveNumberCount:      2,
@@ -150,29 +238,29 @@ func TestElapsedTime(t *testing.T) {
 
 	start = time.Now()
 	err = strgo.Byte("john_doe.123", &strgo.ByteCondition{
-		MinLength:        3,
-		MaxLength:        20,
-		OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.'}...),
-		MustBeFollowedBy: [2][]byte{{'_', '.'}, strgo.AlphanumericByte},
-		MayContainsOnce:  []byte{'_', '.'},
+		MinLength:          3,
+		MaxLength:          20,
+		OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+		MayContainsOnce:    strgo.CharSetOf('_', '.'),
 	})
 	elapsed = time.Since(start)
 	log.Printf("strgo bytes username (john_doe.123) 		%s %v", elapsed, err)
 
 	start = time.Now()
 	err = strgo.Byte("john+doe123@email", &strgo.ByteCondition{
-		MinLength:        4,
-		MaxLength:        255,
-		OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.', '@', '-', '+'}...),
-		MustBeFollowedBy: [2][]byte{{'_', '.', '@', '-', '+'}, strgo.AlphanumericByte},
-		MustContainsOnce: []byte{'@'},
+		MinLength:          4,
+		MaxLength:          255,
+		OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.', '@', '-', '+')),
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.', '@', '-', '+'), strgo.AlphanumericSet}},
+		MustContainsOnce:   strgo.CharSetOf('@'),
 	})
 	elapsed = time.Since(start)
 	log.Printf("strgo bytes email (john+doe123@email) 		%s %v", elapsed, err)
 
 	start = time.Now()
 	err = strgo.Byte("John_Doe.123", &strgo.ByteCondition{
-		OnlyContains:                strgo.CharsByte,
+		OnlyContains:                strgo.CharsSet,
 		AtLeastHaveUpperLetterCount: 2,
 		AtLeastHaveLowerLetterCount: 2,
 		AtLeastHaveNumberCount:      2,
@@ -223,3 +311,34 @@ func TestElapsedTime(t *testing.T) {
 	elapsed = time.Since(start)
 	log.Printf("regex email (john+doe123@email)			%s", elapsed)
 }
+
+func benchmarkBlocklist() []string {
+	var words []string
+	for i := 0; i < 5000; i++ {
+		words = append(words, "blocked"+strconv.Itoa(i)+"word")
+	}
+	return words
+}
+
+func BenchmarkStringBlocklist(b *testing.B) {
+	words := benchmarkBlocklist()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = strgo.String(benchmarkLongText, &strgo.StringCondition{
+			MustNotContainsWord: words,
+		})
+	}
+}
+
+func BenchmarkStringValidatorBlocklist(b *testing.B) {
+	v, err := strgo.CompileString(&strgo.StringCondition{
+		MustNotContainsWord: benchmarkBlocklist(),
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = v.Validate(benchmarkLongText)
+	}
+}
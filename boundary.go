@@ -0,0 +1,49 @@
+package strgo
+
+import "github.com/rivo/uniseg"
+
+// boundaries tells if a match in a text is a whole word, by the delimiters of
+// the condition or, without delimiters, by the Unicode word boundaries. The
+// Unicode boundaries are only found when the first match is checked, so a text
+// without any match doesn't pay for them.
+type boundaries struct {
+	delimiters CharSet
+	found      bool
+	bits       []uint64
+}
+
+// whole tells if the match from start to end in the text is a whole word.
+func (b *boundaries) whole(text string, start, end int) bool {
+	if !b.delimiters.IsEmpty() {
+		return (start == 0 || b.delimiters.Contains(text[start-1])) &&
+			(end == len(text) || b.delimiters.Contains(text[end]))
+	}
+	if !b.found {
+		b.find(text)
+	}
+
+	return b.bits[start>>6]&(1<<(start&63)) != 0 && b.bits[end>>6]&(1<<(end&63)) != 0
+}
+
+// find marks the Unicode word boundaries of the text (UAX #29), the start and
+// the end of the text included.
+func (b *boundaries) find(text string) {
+	n := len(text)>>6 + 1
+	if cap(b.bits) < n {
+		b.bits = make([]uint64, n)
+	}
+	b.bits = b.bits[:n]
+	for i := range b.bits {
+		b.bits[i] = 0
+	}
+
+	pos, state := 0, -1
+	b.bits[0] = 1
+	for rest := text; rest != ""; {
+		var word string
+		word, rest, state = uniseg.FirstWordInString(rest, state)
+		pos += len(word)
+		b.bits[pos>>6] |= 1 << (pos & 63)
+	}
+	b.found = true
+}
@@ -2,198 +2,686 @@ package strgo
 
 import (
 	"errors"
-	"strconv"
+	"math/bits"
+	"sync"
+	"unicode/utf8"
 )
 
-const asciiMaxLen = 128
-
 const asciiMaxDec = 127
 
-type asciis [asciiMaxLen]byte
-
 type ByteCondition struct {
-	MinLength                   int
-	MaxLength                   int
-	OnlyContains                []byte
-	OnlyContainsPrefix          []byte
-	OnlyContainsSuffix          []byte
-	MustContains                []byte
-	MustContainsOnce            []byte
-	MustNotContains             []byte
-	MustNotContainsPrefix       []byte
-	MustNotContainsSuffix       []byte
-	MustBeFollowedBy            [2][]byte
-	MayContainsOnce             []byte
+	MinLength             int
+	MaxLength             int
+	LengthUnit            LengthUnit
+	OnlyContains          CharSet
+	OnlyContainsPrefix    CharSet
+	OnlyContainsSuffix    CharSet
+	MustContains          CharSet
+	MustContainsOnce      CharSet
+	MustNotContains       CharSet
+	MustNotContainsPrefix CharSet
+	MustNotContainsSuffix CharSet
+	// MustBeFollowedBy, MustBePrecededBy and MustBeSurroundedBy are rule
+	// pairs of chars and the neighbours they need: the next char, the previous
+	// one, or both. The char can't end, start, or do either with the string.
+	// MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('.'), strgo.AlphanumericSet}} allows
+	// "a.b" but not ".ab", "ab." or "a..b".
+	MustBeFollowedBy   [][2]CharSet
+	MustBePrecededBy   [][2]CharSet
+	MustBeSurroundedBy [][2]CharSet
+	// MustNotBeFollowedBy and MustNotBePrecededBy are rule pairs of chars and
+	// the neighbours they must not have on that side.
+	MustNotBeFollowedBy [][2]CharSet
+	MustNotBePrecededBy [][2]CharSet
+	// Adjacency is a list of rules on the neighbours of chars, for the rules
+	// the MustBe*By fields can't write, like the MustNotBeSurroundedBy rule
+	// {Chars: strgo.CharSetOf('-'), Neighbors: strgo.CharSetOf('-'),
+	// Direction: strgo.DirectionBoth, Not: true}.
+	Adjacency                   []AdjacencyRule
+	MayContainsOnce             CharSet
 	AtLeastHaveUpperLetterCount int
 	AtLeastHaveLowerLetterCount int
 	AtLeastHaveNumberCount      int
 	AtLeastHaveSpecialCharCount int
+	// CharCount limits how many times a char appears, like "at most 2
+	// hyphens" with {'-': {Max: 2}}.
+	CharCount map[byte]Range
+	// ClassCounts limits how many chars of a set the string has, like "at
+	// most 4 digits" with {Set: strgo.NumericSet, Max: 4}. The
+	// AtLeastHave*Count fields are shorthands for the upper case letters, the
+	// lower case letters, the digits and the chars that aren't alphanumeric.
+	ClassCounts []ClassCount
+	// MaxRepeatRun is how many times the same char may appear in a row, so 2
+	// allows "aa" but not "aaa".
+	MaxRepeatRun int
+	// MaxSequentialRun is how long a run of chars that follow each other in
+	// the alphabet, the digits or a keyboard row may be, ascending or
+	// descending, so 3 allows "abc" and "321" but not "abcd" or "qwer".
+	MaxSequentialRun int
+	// MaxSameClassRun is how many upper case letters, lower case letters,
+	// digits or other chars may appear in a row, so 4 allows "1234" but not
+	// "12345".
+	MaxSameClassRun int
+	// PositionSets are the chars allowed at an index of the string, a negative
+	// index counts from the end, so -1 is the last char. PrefixSets and
+	// SuffixSets are the sets of the first and the last chars, in order. An
+	// index past the end of the string isn't checked, use MinLength for that.
+	// An index must be between -65536 and 65535.
+	PositionSets map[int]CharSet
+	PrefixSets   []CharSet
+	SuffixSets   []CharSet
+}
+
+// ByteValidator is a compiled ByteCondition. The sets are compiled once by
+// CompileByte into a mask per char, which tells the rules the char fires, so
+// the chars that fire no rule are skipped in bulk.
+// A ByteValidator is immutable and safe for concurrent use by multiple goroutines.
+type ByteValidator struct {
+	minLength                int
+	maxLength                int
+	lengthUnit               LengthUnit
+	hasOnlyContainsPrefix    bool
+	hasOnlyContainsSuffix    bool
+	hasMustNotContainsPrefix bool
+	hasMustNotContainsSuffix bool
+	mask                     [256]ruleMask
+	onlyContainsPrefix       CharSet
+	onlyContainsSuffix       CharSet
+	mustNotContainsPrefix    CharSet
+	mustNotContainsSuffix    CharSet
+	mustContains             CharSet
+	mustContainsOnce         CharSet
+	adjacency                []adjacencyRule
+	adjacents                []adjacent
+	// adjacentIndex is the index of the adjacents entry of each char.
+	adjacentIndex [asciiMaxDec + 1]uint8
+	charCounts    []charCount
+	// charCountIndex is the index of the CharCount rule of each char.
+	charCountIndex   [asciiMaxDec + 1]uint8
+	classCounts      []classCount
+	maxRepeatRun     int
+	maxSequentialRun int
+	maxSameClassRun  int
+	// front and back are the sets of the first chars and of the last ones,
+	// sorted by their index from their end.
+	front []positionSet
+	back  []positionSet
+	// edges tells if there are rules on the first chars or on the last ones.
+	edges bool
+	// adjacencyBuf, adjacentBuf and classCountBuf back the slices of the
+	// common conditions, so the validator Byte compiles doesn't allocate.
+	adjacencyBuf  [4]adjacencyRule
+	adjacentBuf   [4]adjacent
+	classCountBuf [4]classCount
 }
 
+// ruleMask is the set of rules a char fires, anywhere in the string.
+type ruleMask uint32
+
+const (
+	maskOnlyContains ruleMask = 1 << iota
+	maskMustNotContains
+	maskMustContains
+	maskMayContainsOnce
+	maskAdjacency
+	maskNotASCII
+	maskCharCount
+	maskRun
+)
+
+// The class counts have a bit each, from maskClassCount up, so a satisfied
+// class stops firing on its chars.
+const (
+	classShift              = 8
+	maskClassCount ruleMask = 1 << classShift
+	maxClassCounts          = 32 - classShift
+)
+
 // Byte matches the string based on the ByteCondition.
 // If one doesn't match, it will return an error.
 // This function can only validate ASCII characters (0-127).
 // Ref: https://en.wikipedia.org/wiki/ASCII
+// The condition is only read, so it can be shared between goroutines.
+//
+// Byte caches the validators of the first conditions it meets, by their value,
+// and compiles the others on every call, into a pooled validator. Use
+// CompileByte to validate many strings with the same condition.
 func Byte(text string, cond *ByteCondition) error {
-	if text == "" {
-		return errors.New("the string is empty")
+	if cond == nil {
+		return errors.New("the condition is nil")
 	}
+	if v, err := cachedByte(cond); v != nil || err != nil {
+		if err != nil {
+			return err
+		}
 
-	textLen := len(text)
+		return v.Validate(text)
+	}
 
-	if cond.MinLength > 0 && textLen < cond.MinLength {
-		return errors.New("the string length cannot be less than " + strconv.Itoa(cond.MinLength))
+	v := byteValidators.Get().(*ByteValidator)
+	defer byteValidators.Put(v)
+	if err := v.compile(cond); err != nil {
+		return err
 	}
-	if cond.MaxLength > 0 && textLen > cond.MaxLength {
-		return errors.New("the string length cannot be more than " + strconv.Itoa(cond.MaxLength))
+
+	return v.Validate(text)
+}
+
+// blankMask is the mask of a condition without rules, the chars above 127
+// always fail.
+var blankMask = func() (mask [256]ruleMask) {
+	for c := asciiMaxDec + 1; c < len(mask); c++ {
+		mask[c] = maskNotASCII
 	}
 
-	var (
-		onlyContains,
-		onlyContainsPrefix,
-		onlyContainsSuffix,
-		mustContains,
-		mustNotContains,
-		mustNotContainsPrefix,
-		mustNotContainsSuffix,
-		mustBeFollowedBy,
-		mustBeFollowedByPairs,
-		mayContainsOnce asciis
-		atLeastHaveUpperLetterCount,
-		atLeastHaveLowerLetterCount,
-		atLeastHaveNumberCount,
-		atLeastHaveSpecialCharCount int
-	)
+	return mask
+}()
+
+// byteValidators holds the validators Byte compiles into, so a one-shot call
+// doesn't allocate the lookup tables.
+var byteValidators = sync.Pool{
+	New: func() interface{} {
+		return &ByteValidator{}
+	},
+}
 
-	if cond.OnlyContains != nil {
-		setASCIICond(&onlyContains, &cond.OnlyContains)
+// CompileByte builds the lookup tables of the ByteCondition once and returns
+// a ByteValidator. The condition is only read, later changes to it don't
+// affect the returned validator.
+func CompileByte(cond *ByteCondition) (*ByteValidator, error) {
+	v := &ByteValidator{}
+	if err := v.compile(cond); err != nil {
+		return nil, err
 	}
-	if cond.OnlyContainsPrefix != nil {
-		setASCIICond(&onlyContainsPrefix, &cond.OnlyContainsPrefix)
+
+	return v, nil
+}
+
+// MustCompileByte is like CompileByte but panics if the condition can't be
+// compiled. It's meant for package-level variables.
+func MustCompileByte(cond *ByteCondition) *ByteValidator {
+	v, err := CompileByte(cond)
+	if err != nil {
+		panic(err)
 	}
-	if cond.OnlyContainsSuffix != nil {
-		setASCIICond(&onlyContainsSuffix, &cond.OnlyContainsSuffix)
+
+	return v
+}
+
+// compile builds the lookup tables of the condition into v, overwriting what
+// it held before.
+func (v *ByteValidator) compile(cond *ByteCondition) error {
+	if cond == nil {
+		return errors.New("the condition is nil")
 	}
-	if cond.MustContains != nil {
-		setASCIICond(&mustContains, &cond.MustContains)
+
+	*v = ByteValidator{
+		minLength:                cond.MinLength,
+		maxLength:                cond.MaxLength,
+		lengthUnit:               cond.LengthUnit.orDefault(LengthBytes),
+		hasOnlyContainsPrefix:    !cond.OnlyContainsPrefix.IsEmpty(),
+		hasOnlyContainsSuffix:    !cond.OnlyContainsSuffix.IsEmpty(),
+		hasMustNotContainsPrefix: !cond.MustNotContainsPrefix.IsEmpty(),
+		hasMustNotContainsSuffix: !cond.MustNotContainsSuffix.IsEmpty(),
+		onlyContainsPrefix:       cond.OnlyContainsPrefix.members(),
+		onlyContainsSuffix:       cond.OnlyContainsSuffix.members(),
+		mustNotContainsPrefix:    cond.MustNotContainsPrefix.members(),
+		mustNotContainsSuffix:    cond.MustNotContainsSuffix.members(),
+		mustContains:             cond.MustContains.Union(cond.MustContainsOnce).kept(),
+		mustContainsOnce:         cond.MustContainsOnce.members(),
+		maxRepeatRun:             cond.MaxRepeatRun,
+		maxSequentialRun:         cond.MaxSequentialRun,
+		maxSameClassRun:          cond.MaxSameClassRun,
 	}
-	if cond.MustContainsOnce != nil {
-		setASCIICondDouble(&mustContains, &mayContainsOnce, &cond.MustContainsOnce)
+
+	v.mask = blankMask
+
+	var err error
+	if v.charCounts, err = compileCharCounts(cond.CharCount); err != nil {
+		return err
 	}
-	if cond.MustNotContains != nil {
-		setASCIICond(&mustNotContains, &cond.MustNotContains)
+	if v.classCounts, err = compileClassCounts(cond, v.classCountBuf[:0]); err != nil {
+		return err
 	}
-	if cond.MustNotContainsPrefix != nil {
-		setASCIICond(&mustNotContainsPrefix, &cond.MustNotContainsPrefix)
+	if v.adjacency, err = compileAdjacency(cond, v.adjacencyBuf[:0]); err != nil {
+		return err
 	}
-	if cond.MustNotContainsSuffix != nil {
-		setASCIICond(&mustNotContainsSuffix, &cond.MustNotContainsSuffix)
+	v.adjacents = compileAdjacent(v.adjacency, v.adjacentBuf[:0], &v.adjacentIndex)
+	if v.front, v.back, err = compilePositions(cond); err != nil {
+		return err
 	}
-	if cond.MayContainsOnce != nil {
-		setASCIICond(&mayContainsOnce, &cond.MayContainsOnce)
+	v.edges = v.hasOnlyContainsPrefix || v.hasOnlyContainsSuffix || v.hasMustNotContainsPrefix ||
+		v.hasMustNotContainsSuffix || len(v.front) > 0 || len(v.back) > 0
+
+	if !cond.OnlyContains.IsEmpty() {
+		setMask(&v.mask, maskOnlyContains, cond.OnlyContains.Complement())
 	}
-	if cond.MustBeFollowedBy[0] != nil && cond.MustBeFollowedBy[1] != nil {
-		setASCIICond(&mustBeFollowedBy, &cond.MustBeFollowedBy[0])
-		setASCIICond(&mustBeFollowedByPairs, &cond.MustBeFollowedBy[1])
+	setMask(&v.mask, maskMustNotContains, cond.MustNotContains)
+	setMask(&v.mask, maskMustContains, cond.MustContains)
+	setMask(&v.mask, maskMustContains, cond.MustContainsOnce)
+	setMask(&v.mask, maskMayContainsOnce, cond.MayContainsOnce)
+	setMask(&v.mask, maskMayContainsOnce, cond.MustContainsOnce)
+	for _, r := range v.adjacency {
+		setMask(&v.mask, maskAdjacency, r.chars)
 	}
-	atLeastHaveUpperLetterCount = cond.AtLeastHaveUpperLetterCount
-	atLeastHaveLowerLetterCount = cond.AtLeastHaveLowerLetterCount
-	atLeastHaveNumberCount = cond.AtLeastHaveNumberCount
-	atLeastHaveSpecialCharCount = cond.AtLeastHaveSpecialCharCount
 
-	textLenMaxIndex := textLen - 1
+	for k, cc := range v.classCounts {
+		setMask(&v.mask, maskClassCount<<k, cc.set)
+	}
+	for i, cc := range v.charCounts {
+		v.mask[cc.char] |= maskCharCount
+		v.charCountIndex[cc.char] = uint8(i)
+	}
+	if v.maxRepeatRun > 0 || v.maxSequentialRun > 0 || v.maxSameClassRun > 0 {
+		setMask(&v.mask, maskRun, asciiSet)
+	}
 
-	for i, v := range text {
-		if v > asciiMaxDec {
-			return errors.New("the char: " + string(v) + ", is not a valid ascii format")
+	return nil
+}
+
+// Validate matches the string based on the compiled ByteCondition.
+// If one doesn't match, it will return a *ValidationError.
+func (v *ByteValidator) Validate(text string) error {
+	errs := collector{}
+	validateText(v, text, &errs)
+
+	return errs.err()
+}
+
+// ValidateAll matches the string based on the compiled ByteCondition like
+// Validate, but it doesn't stop at the first violated rule. Every violation is
+// returned as ValidationErrors, ordered by their offset.
+func (v *ByteValidator) ValidateAll(text string) error {
+	errs := collector{all: true}
+	validateText(v, text, &errs)
+
+	return errs.err()
+}
+
+// ValidateBytes matches the byte slice based on the compiled ByteCondition,
+// the same as Validate but without converting it to a string.
+func (v *ByteValidator) ValidateBytes(text []byte) error {
+	errs := collector{}
+	validateText(v, text, &errs)
+
+	return errs.err()
+}
+
+func validateText[T string | []byte](v *ByteValidator, text T, errs *collector) {
+	if len(text) == 0 {
+		errs.add(&ValidationError{Rule: RuleEmpty, Offset: -1})
+		return
+	}
+	if checkLength(text, v.minLength, v.maxLength, v.lengthUnit, errs) {
+		return
+	}
+
+	s := v.newScan(errs)
+	if scanText(&s, text, 0, len(text), true) {
+		return
+	}
+	s.finish()
+}
+
+// byteScan is the state of one ByteValidator run. The chars are fed to
+// scanText, which only remembers the last char and a pending next char
+// check between two calls, so the same code validates strings, byte slices and
+// streams.
+type byteScan struct {
+	v       *ByteValidator
+	errs    *collector
+	stop    bool
+	skip    int
+	prev    byte
+	pending int
+	// pendingChar is the char at pending, whose next char is still to check.
+	pendingChar byte
+	// active is the mask of the rules that are still to check, a rule is
+	// removed once it can't fire anymore, like a satisfied count.
+	active          ruleMask
+	mustContains    CharSet
+	mayContainsOnce CharSet
+	// repeatRun, sequenceRun and classRun are the lengths of the runs that end
+	// with the last char, sequenceDir tells if the sequence goes up or down.
+	repeatRun   int
+	sequenceRun int
+	sequenceDir int8
+	classRun    int
+	// front is the index of the next front set, and back the index of the
+	// next back set, the back sets are met from the farthest from the end.
+	front int
+	back  int
+	// counts are the counts of the CharCount chars then of the class counts,
+	// moreCounts holds the ones that don't fit, so the common conditions
+	// don't allocate.
+	counts     [8]int32
+	moreCounts []int32
+}
+
+func (v *ByteValidator) newScan(errs *collector) byteScan {
+	var more []int32
+	if n := len(v.charCounts) + len(v.classCounts) - 8; n > 0 {
+		more = make([]int32, n)
+	}
+
+	return byteScan{
+		moreCounts:   more,
+		v:            v,
+		errs:         errs,
+		pending:      -1,
+		active:       ^ruleMask(0),
+		mustContains: v.mustContains,
+		back:         len(v.back) - 1,
+	}
+}
+
+// add records the violation, it returns true if the validation must stop.
+func (s *byteScan) add(e *ValidationError) bool {
+	if s.errs.add(e) {
+		s.stop = true
+	}
+
+	return s.stop
+}
+
+// scanText feeds text[:limit] to the scan, base is the offset of text[0] in the
+// whole input. The bytes after limit are only read to decode a non-ASCII char.
+// final tells if the text ends the input. It returns true if the validation must stop.
+//
+// The chars that fire no active rule are skipped in bulk, only the first and
+// the last chars, and the char after a char with a rule on its next char, are
+// always stepped.
+func scanText[T string | []byte](s *byteScan, text T, base, limit int, final bool) bool {
+	v := s.v
+	lastIndex, fast := -1, limit
+	if final {
+		lastIndex = len(text) - 1
+		if end := len(text) - 1; end < fast {
+			fast = end
+		}
+	}
+	for i := 0; i < limit; i++ {
+		if s.skip > 0 {
+			s.skip--
+			continue
 		}
-		if i == 0 {
-			if cond.OnlyContainsPrefix != nil && onlyContainsPrefix[v] < 1 {
-				return errors.New("the string cannot contain prefix char: " + string(v))
+		if s.pending < 0 && base+i > 0 {
+			mask, active, stop := &v.mask, s.active, fast
+			if s.front < len(v.front) && v.front[s.front].index-base < stop {
+				stop = v.front[s.front].index - base
+			}
+			if final && s.back >= 0 && lastIndex-v.back[s.back].index < stop {
+				stop = lastIndex - v.back[s.back].index
 			}
-			if cond.MustNotContainsPrefix != nil && mustNotContainsPrefix[v] > 0 {
-				return errors.New("the string must not contain prefix: " + string(v))
+			for i < stop && mask[text[i]]&active == 0 {
+				i++
+			}
+			if i == limit {
+				break
 			}
 		}
-		if i == textLenMaxIndex {
-			if cond.OnlyContainsSuffix != nil && onlyContainsSuffix[v] < 1 {
-				return errors.New("the string cannot contain suffix char: " + string(v))
+		c := text[i]
+		if c > asciiMaxDec {
+			end := i + utf8.UTFMax
+			if end > len(text) {
+				end = len(text)
 			}
-			if cond.MustNotContainsSuffix != nil && mustNotContainsSuffix[v] > 0 {
-				return errors.New("the string must not contain suffix: " + string(v))
+			r, size := utf8.DecodeRuneInString(string(text[i:end]))
+			s.skip = size - 1
+			if s.invalid(r, base+i) {
+				return true
 			}
+			continue
 		}
-		if cond.OnlyContains != nil && onlyContains[v] < 1 {
-			return errors.New("the string cannot contain char: " + string(v))
+		prev := s.prev
+		if i > 0 {
+			prev = text[i-1]
 		}
-		if cond.MustNotContains != nil && mustNotContains[v] > 0 {
-			return errors.New("the string must not contain char: " + string(v))
+		fromEnd := -1
+		if final {
+			fromEnd = lastIndex - i
 		}
-		if (cond.MustContains != nil || cond.MustContainsOnce != nil) && mustContains[v] > 0 {
-			mustContains[v] = 0
+		if s.step(c, prev, base+i, fromEnd) {
+			return true
 		}
-		if (cond.MayContainsOnce != nil || cond.MustContainsOnce != nil) && mayContainsOnce[v] > 0 {
-			if mayContainsOnce[v] > 1 {
-				return errors.New("the char: " + string(v) + ", must be appeared once in the string")
-			}
-			mayContainsOnce[v] += 1
+	}
+	if limit > 0 {
+		s.prev = text[limit-1]
+	}
+
+	return false
+}
+
+// invalid handles the non-ASCII char r at offset i.
+func (s *byteScan) invalid(r rune, i int) bool {
+	if s.pending >= 0 && s.checkNext(-1) {
+		return true
+	}
+
+	return s.add(&ValidationError{Rule: RuleNotASCII, Char: r, Offset: i})
+}
+
+// step handles the ASCII char c at offset i, prev is the byte before it, and
+// fromEnd is the index of c from the end of the input, -1 if it isn't known yet.
+func (s *byteScan) step(c, prev byte, i, fromEnd int) bool {
+	v := s.v
+	last := fromEnd == 0
+	if s.pending >= 0 && s.checkNext(int(c)) {
+		return true
+	}
+	if v.edges && s.edges(c, i, fromEnd) {
+		return true
+	}
+
+	m := v.mask[c] & s.active
+	if m == 0 {
+		return false
+	}
+	if m&maskOnlyContains != 0 && s.add(&ValidationError{Rule: RuleOnlyContains, Char: rune(c), Offset: i}) {
+		return true
+	}
+	if m&maskMustNotContains != 0 && s.add(&ValidationError{Rule: RuleMustNotContains, Char: rune(c), Offset: i}) {
+		return true
+	}
+	if m&maskMustContains != 0 {
+		s.mustContains.remove(c)
+		if s.mustContains.IsEmpty() {
+			s.active &^= maskMustContains
+		}
+	}
+	if m&maskMayContainsOnce != 0 {
+		if s.mayContainsOnce.Contains(c) && s.add(v.onceError(rune(c), i)) {
+			return true
+		}
+		s.mayContainsOnce.add(c)
+	}
+	if m&maskAdjacency != 0 {
+		a := &v.adjacents[v.adjacentIndex[c]]
+		n := int(prev)
+		if i == 0 || prev > asciiMaxDec {
+			n = -1
 		}
-		if cond.MustBeFollowedBy[0] != nil && cond.MustBeFollowedBy[1] != nil && mustBeFollowedBy[v] > 0 {
-			if i == 0 || (i+1) == textLen {
-				return errors.New("the char: " + string(v) + ", must be followed with at least one of these characters: " + string(cond.MustBeFollowedBy[1]))
+		switch {
+		case !allows(a.prev, a.openPrev, n):
+			if s.add(v.adjacencyError(c, i, n, false)) {
+				return true
 			}
-			if i > 0 && i < textLen && mustBeFollowedByPairs[text[i-1]] < 1 {
-				return errors.New("the char: " + string(v) + ", must be followed with at least one of these characters: " + string(cond.MustBeFollowedBy[1]))
+		case last:
+			if a.checkNext && !a.openNext && s.add(v.adjacencyError(c, i, -1, true)) {
+				return true
 			}
-			if (i+1) < textLen && mustBeFollowedByPairs[text[i+1]] < 1 {
-				return errors.New("the char: " + string(v) + ", must be followed with at least one of these characters: " + string(cond.MustBeFollowedBy[1]))
+		case a.checkNext:
+			s.pending = i
+			s.pendingChar = c
+		}
+	}
+	if m&maskCharCount != 0 {
+		index := v.charCountIndex[c]
+		count := s.count(int(index))
+		*count++
+		if limit := v.charCounts[index].Max; limit > 0 && int(*count) == limit+1 && s.add(&ValidationError{Rule: RuleCharCount, Char: rune(c), Offset: i, Limit: limit, Count: limit + 1}) {
+			return true
+		}
+	}
+	for classes := uint32(m >> classShift); classes != 0; classes &= classes - 1 {
+		k := bits.TrailingZeros32(classes)
+		cc := &v.classCounts[k]
+		count := s.count(len(v.charCounts) + k)
+		*count++
+		if cc.Max == 0 {
+			if int(*count) >= cc.Min {
+				s.active &^= maskClassCount << k
 			}
+			continue
+		}
+		if int(*count) == cc.Max+1 && s.add(v.classError(k, rune(c), i, cc.Max, cc.Max+1)) {
+			return true
 		}
-		if atLeastHaveUpperLetterCount > 0 && (v >= 'A' && v <= 'Z') {
-			atLeastHaveUpperLetterCount -= 1
+	}
+	if m&maskRun != 0 && s.run(c, prev, i) {
+		return true
+	}
+
+	return false
+}
+
+// edges checks the rules on the first chars and on the last ones, for the char
+// c at offset i, fromEnd is its index from the end of the input, -1 if it isn't
+// known yet.
+func (s *byteScan) edges(c byte, i, fromEnd int) bool {
+	v := s.v
+	if i == 0 {
+		if v.hasOnlyContainsPrefix && !v.onlyContainsPrefix.Contains(c) && s.add(&ValidationError{Rule: RuleOnlyContainsPrefix, Char: rune(c), Offset: i}) {
+			return true
+		}
+		if v.hasMustNotContainsPrefix && v.mustNotContainsPrefix.Contains(c) && s.add(&ValidationError{Rule: RuleMustNotContainsPrefix, Char: rune(c), Offset: i}) {
+			return true
+		}
+	}
+	if fromEnd == 0 {
+		if v.hasOnlyContainsSuffix && !v.onlyContainsSuffix.Contains(c) && s.add(&ValidationError{Rule: RuleOnlyContainsSuffix, Char: rune(c), Offset: i}) {
+			return true
 		}
-		if atLeastHaveLowerLetterCount > 0 && (v >= 'a' && v <= 'z') {
-			atLeastHaveLowerLetterCount -= 1
+		if v.hasMustNotContainsSuffix && v.mustNotContainsSuffix.Contains(c) && s.add(&ValidationError{Rule: RuleMustNotContainsSuffix, Char: rune(c), Offset: i}) {
+			return true
 		}
-		if atLeastHaveNumberCount > 0 && (v >= '0' && v <= '9') {
-			atLeastHaveNumberCount -= 1
+	}
+	for s.front < len(v.front) && v.front[s.front].index < i {
+		s.front++
+	}
+	if s.front < len(v.front) && v.front[s.front].index == i && !v.front[s.front].set.Contains(c) &&
+		s.add(positionError(c, i, i, v.front[s.front].set)) {
+		return true
+	}
+	if fromEnd >= 0 {
+		for s.back >= 0 && v.back[s.back].index > fromEnd {
+			s.back--
 		}
-		if atLeastHaveSpecialCharCount > 0 && (v < 'A' || v > 'Z') && (v < 'a' || v > 'z') && (v < '0' || v > '9') {
-			atLeastHaveSpecialCharCount -= 1
+		if s.back >= 0 && v.back[s.back].index == fromEnd && !v.back[s.back].set.Contains(c) &&
+			s.add(positionError(c, i, -fromEnd-1, v.back[s.back].set)) {
+			return true
 		}
 	}
-	if cond.MustContains != nil || cond.MustContainsOnce != nil {
-		for b, v := range mustContains {
-			if v > 0 {
-				return errors.New("the string must contain char: " + string(rune(b)))
+
+	return false
+}
+
+// count returns the i-th count, the CharCount rules come first.
+func (s *byteScan) count(i int) *int32 {
+	if i < len(s.counts) {
+		return &s.counts[i]
+	}
+
+	return &s.moreCounts[i-len(s.counts)]
+}
+
+// checkNext checks the next char n of the pending char, n is -1 for the end of
+// the string or a non-ASCII char.
+func (s *byteScan) checkNext(n int) bool {
+	v := s.v
+	c, i := s.pendingChar, s.pending
+	s.pending = -1
+	a := &v.adjacents[v.adjacentIndex[c]]
+
+	return !allows(a.next, a.openNext, n) && s.add(v.adjacencyError(c, i, n, true))
+}
+
+// finish checks the rules that need the whole input.
+func (s *byteScan) finish() {
+	v := s.v
+	if s.pending >= 0 && s.checkNext(-1) {
+		return
+	}
+	if !s.mustContains.IsEmpty() {
+		for _, b := range s.mustContains.Bytes() {
+			rule := RuleMustContains
+			if v.mustContainsOnce.Contains(b) {
+				rule = RuleMustContainsOnce
+			}
+			if s.add(&ValidationError{Rule: rule, Char: rune(b), Offset: -1, Limit: 1}) {
+				return
 			}
 		}
 	}
-	if atLeastHaveUpperLetterCount > 0 {
-		return errors.New("the string must have at least " + strconv.Itoa(cond.AtLeastHaveUpperLetterCount) + " upper case letter(s)")
+	for k, cc := range v.classCounts {
+		if count := int(*s.count(len(v.charCounts) + k)); count < cc.Min && s.add(v.classError(k, 0, -1, cc.Min, count)) {
+			return
+		}
 	}
-	if atLeastHaveLowerLetterCount > 0 {
-		return errors.New("the string must have at least " + strconv.Itoa(cond.AtLeastHaveLowerLetterCount) + " lower case letter(s)")
+	for i, cc := range v.charCounts {
+		if count := int(*s.count(i)); count < cc.Min && s.add(&ValidationError{Rule: RuleCharCount, Char: rune(cc.char), Offset: -1, Limit: cc.Min, Count: count}) {
+			return
+		}
 	}
-	if atLeastHaveNumberCount > 0 {
-		return errors.New("the string must have at least " + strconv.Itoa(cond.AtLeastHaveNumberCount) + " number(s)")
+}
+
+func (v *ByteValidator) onceError(c rune, offset int) *ValidationError {
+	rule := RuleMayContainsOnce
+	if v.mustContainsOnce.Contains(byte(c)) {
+		rule = RuleMustContainsOnce
 	}
-	if atLeastHaveSpecialCharCount > 0 {
-		return errors.New("the string must have at least " + strconv.Itoa(cond.AtLeastHaveSpecialCharCount) + " special char(s)")
+
+	return &ValidationError{Rule: rule, Char: c, Offset: offset, Limit: 1, Count: 2}
+}
+
+// adjacencyError returns the violation of the first rule of the char c that
+// the neighbour n fails, on its next side or its previous one.
+func (v *ByteValidator) adjacencyError(c byte, offset, n int, next bool) *ValidationError {
+	for k := range v.adjacency {
+		r := &v.adjacency[k]
+		if r.chars.Contains(c) && (next && r.next || !next && r.prev) && r.fails(n) {
+			return &ValidationError{Rule: r.rule, Char: rune(c), Offset: offset, Index: r.index, Expected: r.expected()}
+		}
 	}
 
 	return nil
 }
 
-func setASCIICond(c *asciis, b *[]byte) {
-	for _, v := range *b {
-		c[v] = 1
+// classError returns the violation of the k-th class count, the Expected chars
+// are only set for ClassCounts, the shorthands name their class.
+func (v *ByteValidator) classError(k int, c rune, offset, limit, count int) *ValidationError {
+	e := &ValidationError{Rule: v.classCounts[k].rule, Char: c, Offset: offset, Limit: limit, Count: count}
+	if e.Rule == RuleClassCount {
+		e.Expected = string(v.classCounts[k].set.Bytes())
+	}
+
+	return e
+}
+
+func setMask(mask *[256]ruleMask, m ruleMask, set CharSet) {
+	for h, word := range set.bits {
+		for ; word != 0; word &= word - 1 {
+			mask[h<<6|bits.TrailingZeros64(word)] |= m
+		}
 	}
 }
 
-func setASCIICondDouble(c, c2 *asciis, b *[]byte) {
-	for _, v := range *b {
-		c[v] = 1
-		c2[v] = 1
+func checkASCII(b []byte) error {
+	for _, v := range b {
+		if v > asciiMaxDec {
+			return errors.New("the char: " + string(rune(v)) + ", is not a valid ascii format")
+		}
 	}
+
+	return nil
 }
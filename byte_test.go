@@ -1,8 +1,11 @@
 package strgo_test
 
 import (
+	"errors"
 	"github.com/dalikewara/strgo"
 	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -32,11 +35,11 @@ func TestByte_MaxLength(t *testing.T) {
 
 func TestByte_OnlyContains(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		OnlyContains: []byte{'j', 'o', 'h', 'n', 'd', 'e'},
+		OnlyContains: strgo.CharSetOf('j', 'o', 'h', 'n', 'd', 'e'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		OnlyContains: []byte{'j', 'o', 'h', 'n', 'd'},
+		OnlyContains: strgo.CharSetOf('j', 'o', 'h', 'n', 'd'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string cannot contain char: e")
@@ -44,11 +47,11 @@ func TestByte_OnlyContains(t *testing.T) {
 
 func TestByte_OnlyContainsPrefix(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		OnlyContainsPrefix: []byte{'o', 'j'},
+		OnlyContainsPrefix: strgo.CharSetOf('o', 'j'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		OnlyContainsPrefix: []byte{'o', 'k'},
+		OnlyContainsPrefix: strgo.CharSetOf('o', 'k'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string cannot contain prefix char: j")
@@ -56,11 +59,11 @@ func TestByte_OnlyContainsPrefix(t *testing.T) {
 
 func TestByte_OnlyContainsSuffix(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		OnlyContainsSuffix: []byte{'o', 'e'},
+		OnlyContainsSuffix: strgo.CharSetOf('o', 'e'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		OnlyContainsSuffix: []byte{'o', 'k'},
+		OnlyContainsSuffix: strgo.CharSetOf('o', 'k'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string cannot contain suffix char: e")
@@ -68,11 +71,11 @@ func TestByte_OnlyContainsSuffix(t *testing.T) {
 
 func TestByte_MustContains(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustContains: []byte{'n', 'h'},
+		MustContains: strgo.CharSetOf('n', 'h'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustContains: []byte{'n', 'k'},
+		MustContains: strgo.CharSetOf('n', 'k'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string must contain char: k")
@@ -80,16 +83,16 @@ func TestByte_MustContains(t *testing.T) {
 
 func TestByte_MustContainsOnce(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustContainsOnce: []byte{'n', 'h'},
+		MustContainsOnce: strgo.CharSetOf('n', 'h'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustContainsOnce: []byte{'n', 'd', 'h', 'k'},
+		MustContainsOnce: strgo.CharSetOf('n', 'd', 'h', 'k'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string must contain char: k")
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustContainsOnce: []byte{'n', 'o'},
+		MustContainsOnce: strgo.CharSetOf('n', 'o'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the char: o, must be appeared once in the string")
@@ -97,11 +100,11 @@ func TestByte_MustContainsOnce(t *testing.T) {
 
 func TestByte_MustNotContains(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustNotContains: []byte{'k', 'r'},
+		MustNotContains: strgo.CharSetOf('k', 'r'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustNotContains: []byte{'k', 'e'},
+		MustNotContains: strgo.CharSetOf('k', 'e'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string must not contain char: e")
@@ -109,11 +112,11 @@ func TestByte_MustNotContains(t *testing.T) {
 
 func TestByte_MustNotContainsPrefix(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustNotContainsPrefix: []byte{'o', 'h'},
+		MustNotContainsPrefix: strgo.CharSetOf('o', 'h'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustNotContainsPrefix: []byte{'o', 'j'},
+		MustNotContainsPrefix: strgo.CharSetOf('o', 'j'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string must not contain prefix: j")
@@ -121,36 +124,173 @@ func TestByte_MustNotContainsPrefix(t *testing.T) {
 
 func TestByte_MustNotContainsSuffix(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustNotContainsSuffix: []byte{'o', 'h'},
+		MustNotContainsSuffix: strgo.CharSetOf('o', 'h'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustNotContainsSuffix: []byte{'o', 'e'},
+		MustNotContainsSuffix: strgo.CharSetOf('o', 'e'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the string must not contain suffix: e")
 }
 
-func TestByte_MustBeFollowedBy(t *testing.T) {
+func TestByte_MustBeSurroundedBy(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustBeFollowedBy: [2][]byte{{'h', 'd'}, {'m', 'n', 'o'}},
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('h', 'd'), strgo.CharSetOf('m', 'n', 'o')}},
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustBeFollowedBy: [2][]byte{{'o', 'd'}, {'e', 'o', 'd', 'j', 'h'}},
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('o', 'd'), strgo.CharSetOf('e', 'o', 'd', 'j', 'h')}},
 	})
 	assert.NotNil(t, err)
-	assert.EqualError(t, err, "the char: d, must be followed with at least one of these characters: eodjh")
+	assert.EqualError(t, err, "the char: d, must be surrounded with at least one of these characters: eodjh")
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustBeFollowedBy: [2][]byte{{'h', 'o'}, {'d', 'k', 'l'}},
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('h', 'o'), strgo.CharSetOf('d', 'k', 'l')}},
 	})
 	assert.NotNil(t, err)
-	assert.EqualError(t, err, "the char: o, must be followed with at least one of these characters: dkl")
+	assert.EqualError(t, err, "the char: o, must be surrounded with at least one of these characters: dkl")
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MustBeFollowedBy: [2][]byte{{'h', 'o'}, {'d', 'k', 'j'}},
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('h', 'o'), strgo.CharSetOf('d', 'k', 'j')}},
 	})
 	assert.NotNil(t, err)
-	assert.EqualError(t, err, "the char: o, must be followed with at least one of these characters: dkj")
+	assert.EqualError(t, err, "the char: o, must be surrounded with at least one of these characters: dkj")
+}
+
+func TestByte_MustBeFollowedBy(t *testing.T) {
+	cond := &strgo.ByteCondition{MustBeFollowedBy: [][2]strgo.CharSet{{strgo.CharSetOf('-'), strgo.NumericSet}}}
+	assert.Nil(t, strgo.Byte("a-1", cond))
+	assert.Nil(t, strgo.Byte("-1-2", cond))
+	assert.EqualError(t, strgo.Byte("a-b", cond), "the char: -, must be followed with at least one of these characters: 0123456789")
+	assert.True(t, errors.Is(strgo.Byte("a1-", cond), strgo.ErrMustBeFollowedBy))
+}
+
+func TestByte_MustBePrecededBy(t *testing.T) {
+	cond := &strgo.ByteCondition{MustBePrecededBy: [][2]strgo.CharSet{{strgo.CharSetOf('%'), strgo.NumericSet}}}
+	assert.Nil(t, strgo.Byte("50%", cond))
+	assert.Nil(t, strgo.Byte("5%a", cond))
+	assert.EqualError(t, strgo.Byte("%5", cond), "the char: %, must be preceded with at least one of these characters: 0123456789")
+	assert.True(t, errors.Is(strgo.Byte("a%", cond), strgo.ErrMustBePrecededBy))
+}
+
+func TestByte_MustNotBeFollowedBy(t *testing.T) {
+	cond := &strgo.ByteCondition{
+		MustNotBeFollowedBy: [][2]strgo.CharSet{{strgo.CharSetOf('.'), strgo.CharSetOf('.', '-')}},
+		MustNotBePrecededBy: [][2]strgo.CharSet{{strgo.CharSetOf('.'), strgo.CharSetOf('-')}},
+	}
+	assert.Nil(t, strgo.Byte(".a.b.", cond))
+	assert.EqualError(t, strgo.Byte("a..b", cond), "the char: ., must not be followed with any of these characters: .-")
+	assert.EqualError(t, strgo.Byte("a-.b", cond), "the char: ., must not be preceded with any of these characters: -")
+
+	v, err := strgo.CompileByte(cond)
+	assert.Nil(t, err)
+	err = v.ValidateAll("a.-b-.c")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, strgo.RuleMustNotBeFollowedBy, errs[0].Rule)
+	assert.Equal(t, 1, errs[0].Offset)
+	assert.Equal(t, strgo.RuleMustNotBePrecededBy, errs[1].Rule)
+	assert.Equal(t, 5, errs[1].Offset)
+}
+
+func TestByte_AdjacencyPairs(t *testing.T) {
+	cond := &strgo.ByteCondition{
+		MustBeSurroundedBy:  [][2]strgo.CharSet{{strgo.CharSetOf('.'), strgo.AlphanumericSet}},
+		MustBeFollowedBy:    [][2]strgo.CharSet{{strgo.CharSetOf('-'), strgo.NumericSet}, {strgo.CharSetOf('_', '-'), strgo.AlphanumericSet}},
+		MustBePrecededBy:    [][2]strgo.CharSet{{strgo.CharSetOf('_'), strgo.LowerAlphabeticSet}},
+		MustNotBeFollowedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_'), strgo.NumericSet}},
+	}
+	for _, text := range []string{"a.b-1", "v1.2-3", "a_b", "-1"} {
+		assert.Nil(t, strgo.Byte(text, cond), text)
+	}
+	assert.EqualError(t, strgo.Byte("a.b-c", cond), "the char: -, must be followed with at least one of these characters: 0123456789")
+	assert.True(t, errors.Is(strgo.Byte("a.-1", cond), strgo.ErrMustBeSurroundedBy))
+	assert.True(t, errors.Is(strgo.Byte("A_b", cond), strgo.ErrMustBePrecededBy))
+	assert.True(t, errors.Is(strgo.Byte("a_1", cond), strgo.ErrMustNotBeFollowedBy))
+	assert.True(t, errors.Is(strgo.Byte("a_.", cond), strgo.ErrMustBeFollowedBy))
+}
+
+func TestByte_Adjacency(t *testing.T) {
+	v, err := strgo.CompileByte(&strgo.ByteCondition{
+		Adjacency: []strgo.AdjacencyRule{
+			{Chars: strgo.CharSetOf('.'), Neighbors: strgo.AlphanumericSet, Direction: strgo.DirectionBoth},
+			{Chars: strgo.CharSetOf('-'), Neighbors: strgo.NumericSet, Direction: strgo.DirectionNext},
+			{Chars: strgo.CharSetOf('_'), Neighbors: strgo.CharSetOf('_', '-'), Direction: strgo.DirectionBoth, Not: true},
+			{Chars: strgo.CharSetOf('_'), Neighbors: strgo.LowerAlphabeticSet, Direction: strgo.DirectionPrevious},
+		},
+	})
+	assert.Nil(t, err)
+	for _, text := range []string{"a.b-1", "v1.2-3", "a_b", "a_"} {
+		assert.Nil(t, v.Validate(text), text)
+	}
+	assert.EqualError(t, v.Validate("a.b-c"), "the char: -, violates the adjacency rule at index: 1")
+	for text, index := range map[string]int{".ab": 0, "a.-1": 0, "1-": 1, "a__b": 2, "a_-1": 2, "A_b": 3, "_b": 3} {
+		var verr *strgo.ValidationError
+		assert.True(t, errors.As(v.Validate(text), &verr), text)
+		assert.Equal(t, strgo.RuleAdjacency, verr.Rule, text)
+		assert.Equal(t, index, verr.Index, text)
+	}
+
+	err = strgo.Byte("a-b_", &strgo.ByteCondition{
+		MustBeFollowedBy: [][2]strgo.CharSet{{strgo.CharSetOf('-'), strgo.AlphabeticSet}, {strgo.CharSetOf('_'), strgo.AlphabeticSet}},
+	})
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleMustBeFollowedBy, verr.Rule)
+	assert.Equal(t, 1, verr.Index)
+
+	_, err = strgo.CompileByte(&strgo.ByteCondition{Adjacency: []strgo.AdjacencyRule{{Chars: strgo.CharSetOf('.'), Neighbors: strgo.AlphanumericSet}}})
+	assert.EqualError(t, err, "the direction of the adjacency rule: 0, is invalid")
+}
+
+func TestByte_PositionSets(t *testing.T) {
+	upper, digit, dash := strgo.UpperAlphabeticSet, strgo.NumericSet, strgo.CharSetOf('-')
+	cond := &strgo.ByteCondition{
+		MinLength:    9,
+		MaxLength:    9,
+		PrefixSets:   []strgo.CharSet{upper, upper, dash},
+		PositionSets: map[int]strgo.CharSet{3: digit, 4: digit, 5: digit, 6: digit},
+		SuffixSets:   []strgo.CharSet{dash, upper},
+	}
+	assert.Nil(t, strgo.Byte("AB-1234-X", cond))
+	assert.EqualError(t, strgo.Byte("A1-1234-X", cond), "the char: 1, at index: 1, must be one of these characters: ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	assert.EqualError(t, strgo.Byte("AB-1234-x", cond), "the char: x, at index: -1, must be one of these characters: ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+
+	v, err := strgo.CompileByte(cond)
+	assert.Nil(t, err)
+	err = v.ValidateAll("AB-12a4_X")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, strgo.RulePositionSets, errs[0].Rule)
+	assert.Equal(t, 5, errs[0].Offset)
+	assert.Equal(t, 5, errs[0].Index)
+	assert.True(t, errors.Is(errs[1], strgo.ErrPositionSets))
+	assert.Equal(t, 7, errs[1].Offset)
+	assert.Equal(t, -2, errs[1].Index)
+
+	cond = &strgo.ByteCondition{
+		PositionSets: map[int]strgo.CharSet{0: strgo.AlphanumericSet, -2: digit, 5: digit},
+		PrefixSets:   []strgo.CharSet{strgo.LowerAlphabeticSet},
+	}
+	assert.Nil(t, strgo.Byte("a1b", cond))
+	assert.Nil(t, strgo.Byte("b", cond))
+	assert.True(t, errors.Is(strgo.Byte("1ab", cond), strgo.ErrPositionSets))
+	assert.True(t, errors.Is(strgo.Byte("abc", cond), strgo.ErrPositionSets))
+	assert.True(t, errors.Is(strgo.Byte("a1234x5", cond), strgo.ErrPositionSets))
+
+	cond = &strgo.ByteCondition{PositionSets: map[int]strgo.CharSet{65535: digit, -65536: digit}}
+	assert.Nil(t, strgo.Byte("abc", cond))
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(strgo.Byte("1"+strings.Repeat("a", 65534)+"x", cond), &verr))
+	assert.Equal(t, 65535, verr.Index)
+	assert.True(t, errors.As(strgo.Byte(strings.Repeat("a", 65535)+"1", cond), &verr))
+	assert.Equal(t, -65536, verr.Index)
+	assert.Nil(t, strgo.Byte("1"+strings.Repeat("a", 65534)+"1", cond))
+	_, err = strgo.CompileByte(&strgo.ByteCondition{PositionSets: map[int]strgo.CharSet{20000000: digit}})
+	assert.EqualError(t, err, "the position index: 20000000, must be between -65536 and 65535")
+	_, err = strgo.CompileByte(&strgo.ByteCondition{PositionSets: map[int]strgo.CharSet{-65537: digit}})
+	assert.NotNil(t, err)
 }
 
 func TestByte_AtLeastHaveUpperLetterCount(t *testing.T) {
@@ -203,16 +343,144 @@ func TestByte_AtLeastHaveSpecialCharCount(t *testing.T) {
 
 func TestByte_MayContainsOnce(t *testing.T) {
 	err := strgo.Byte("johndoe", &strgo.ByteCondition{
-		MayContainsOnce: []byte{'k', 'r', 'h', 'n', 'j', 'd'},
+		MayContainsOnce: strgo.CharSetOf('k', 'r', 'h', 'n', 'j', 'd'),
 	})
 	assert.Nil(t, err)
 	err = strgo.Byte("johndoe", &strgo.ByteCondition{
-		MayContainsOnce: []byte{'k', 'o'},
+		MayContainsOnce: strgo.CharSetOf('k', 'o'),
 	})
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the char: o, must be appeared once in the string")
 }
 
+func TestByte_CharCount(t *testing.T) {
+	cond := &strgo.ByteCondition{
+		CharCount: map[byte]strgo.Range{
+			'-': {Max: 2},
+			'.': {Min: 1, Max: 3},
+			'v': {Min: 2, Max: 2},
+		},
+	}
+	err := strgo.Byte("v1.2-rc-v", cond)
+	assert.Nil(t, err)
+	err = strgo.Byte("v1.2-r-c-v", cond)
+	assert.EqualError(t, err, "the char: -, must be appeared at most 2 time(s) in the string")
+	err = strgo.Byte("v1-2-v", cond)
+	assert.EqualError(t, err, "the char: ., must be appeared at least 1 time(s) in the string")
+	err = strgo.Byte("v1.2", cond)
+	assert.EqualError(t, err, "the char: v, must be appeared at least 2 time(s) in the string")
+
+	v, err := strgo.CompileByte(cond)
+	assert.Nil(t, err)
+	err = v.ValidateAll("a.b.c.d.e-f")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, strgo.RuleCharCount, errs[0].Rule)
+	assert.Equal(t, 7, errs[0].Offset)
+	assert.Equal(t, 3, errs[0].Limit)
+	assert.True(t, errors.Is(errs[1], strgo.ErrCharCount))
+	assert.Equal(t, -1, errs[1].Offset)
+	assert.Equal(t, 0, errs[1].Count)
+
+	_, err = strgo.CompileByte(&strgo.ByteCondition{CharCount: map[byte]strgo.Range{'-': {Min: 3, Max: 2}}})
+	assert.EqualError(t, err, "the count range of char: -, is invalid")
+	_, err = strgo.CompileByte(&strgo.ByteCondition{CharCount: map[byte]strgo.Range{200: {Max: 2}}})
+	assert.NotNil(t, err)
+}
+
+func TestByte_CharCount_ManyChars(t *testing.T) {
+	counts := map[byte]strgo.Range{}
+	for _, c := range strgo.AlphabeticSet.Bytes() {
+		counts[c] = strgo.Range{Max: 1}
+	}
+	v, err := strgo.CompileByte(&strgo.ByteCondition{CharCount: counts})
+	assert.Nil(t, err)
+	assert.Nil(t, v.Validate("thequickbrownfx"))
+	assert.EqualError(t, v.Validate("thequickbrownfox"), "the char: o, must be appeared at most 1 time(s) in the string")
+	assert.EqualError(t, v.Validate("jumpsoverthelazydogZZ"), "the char: e, must be appeared at most 1 time(s) in the string")
+	assert.EqualError(t, v.Validate("ZZ"), "the char: Z, must be appeared at most 1 time(s) in the string")
+}
+
+func TestByte_ClassCounts(t *testing.T) {
+	cond := &strgo.ByteCondition{
+		AtLeastHaveUpperLetterCount: 1,
+		ClassCounts: []strgo.ClassCount{
+			{Set: strgo.CharSetOf('!', '@', '#', '$'), Min: 2},
+			{Set: strgo.NumericSet, Max: 4},
+		},
+	}
+	err := strgo.Byte("Pass!word@2024", cond)
+	assert.Nil(t, err)
+	err = strgo.Byte("Pass!word 2024", cond)
+	assert.EqualError(t, err, "the string must have at least 2 char(s) of: !@#$")
+	err = strgo.Byte("Pass!word@20245", cond)
+	assert.EqualError(t, err, "the string must have at most 4 char(s) of: 0123456789")
+	err = strgo.Byte("pass!word@2024", cond)
+	assert.EqualError(t, err, "the string must have at least 1 upper case letter(s)")
+
+	v, err := strgo.CompileByte(&strgo.ByteCondition{
+		CharCount: map[byte]strgo.Range{'a': {Max: 9}, 'b': {Max: 9}, 'c': {Max: 9}, 'd': {Max: 9}, 'e': {Max: 9}, 'f': {Max: 9}, 'g': {Max: 9}},
+		ClassCounts: []strgo.ClassCount{
+			{Set: strgo.LowerAlphabeticSet, Min: 3, Max: 5},
+			{Set: strgo.CharSetOf('_'), Min: 1},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.Validate("ab_c"))
+	err = v.ValidateAll("abcdef")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, strgo.RuleClassCount, errs[0].Rule)
+	assert.Equal(t, 'f', errs[0].Char)
+	assert.Equal(t, 5, errs[0].Offset)
+	assert.Equal(t, 6, errs[0].Count)
+	assert.True(t, errors.Is(errs[1], strgo.ErrClassCount))
+	assert.Equal(t, "_", errs[1].Expected)
+	assert.Equal(t, 0, errs[1].Count)
+
+	_, err = strgo.CompileByte(&strgo.ByteCondition{ClassCounts: []strgo.ClassCount{{Set: strgo.NumericSet}, {Set: strgo.NumericSet, Min: 2, Max: 1}}})
+	assert.EqualError(t, err, "the count range of class count: 1, is invalid")
+	_, err = strgo.CompileByte(&strgo.ByteCondition{ClassCounts: make([]strgo.ClassCount, 25)})
+	assert.EqualError(t, err, "the class counts must not be more than 24")
+}
+
+func TestByte_Runs(t *testing.T) {
+	cond := &strgo.ByteCondition{MaxRepeatRun: 2}
+	assert.Nil(t, strgo.Byte("aabbaa", cond))
+	assert.EqualError(t, strgo.Byte("abbb", cond), "the char: b, must not be repeated more than 2 time(s) in a row")
+
+	cond = &strgo.ByteCondition{MaxSequentialRun: 3}
+	for _, text := range []string{"abc", "xCBA", "q1w2e3", "abab", "90-a", "321", "7890", "8901", "1098"} {
+		assert.Nil(t, strgo.Byte(text, cond), text)
+	}
+	for _, text := range []string{"abcd", "aBcD", "x4321", "qwer", "LKJH", "6789", "abcdcba", "3210"} {
+		assert.True(t, errors.Is(strgo.Byte(text, cond), strgo.ErrMaxSequentialRun), text)
+	}
+	assert.EqualError(t, strgo.Byte("pass1234", cond), "the char: 4, must not continue a sequence of more than 3 char(s)")
+
+	cond = &strgo.ByteCondition{MaxSameClassRun: 4}
+	assert.Nil(t, strgo.Byte("Pass1234!", cond))
+	assert.EqualError(t, strgo.Byte("Pass12345", cond), "the char: 5, must not continue a run of more than 4 char(s) of the same class")
+	assert.EqualError(t, strgo.Byte("P@ss!!!!!", cond), "the char: !, must not continue a run of more than 4 char(s) of the same class")
+
+	v, err := strgo.CompileByte(&strgo.ByteCondition{MaxRepeatRun: 1, MaxSequentialRun: 2, MaxSameClassRun: 3})
+	assert.Nil(t, err)
+	err = v.ValidateAll("aaaa1é234")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 4)
+	assert.Equal(t, strgo.RuleMaxRepeatRun, errs[0].Rule)
+	assert.Equal(t, 1, errs[0].Offset)
+	assert.Equal(t, 2, errs[0].Count)
+	assert.Equal(t, strgo.RuleMaxSameClassRun, errs[1].Rule)
+	assert.Equal(t, 3, errs[1].Offset)
+	assert.Equal(t, strgo.RuleNotASCII, errs[2].Rule)
+	assert.Equal(t, strgo.RuleMaxSequentialRun, errs[3].Rule)
+	assert.Equal(t, 9, errs[3].Offset)
+}
+
 func TestUsername(t *testing.T) {
 	/*
 		- `username` can only contain alphanumeric characters, underscores and periods
@@ -223,11 +491,11 @@ func TestUsername(t *testing.T) {
 	*/
 	var validate = func(username string) error {
 		return strgo.Byte(username, &strgo.ByteCondition{
-			MinLength:        3,
-			MaxLength:        20,
-			OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.'}...),
-			MustBeFollowedBy: [2][]byte{{'_', '.'}, strgo.AlphanumericByte},
-			MayContainsOnce:  []byte{'_', '.'},
+			MinLength:          3,
+			MaxLength:          20,
+			OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+			MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+			MayContainsOnce:    strgo.CharSetOf('_', '.'),
 		})
 	}
 	err := validate("johndoe")
@@ -290,11 +558,11 @@ func TestEmail(t *testing.T) {
 	*/
 	var validate = func(email string) error {
 		return strgo.Byte(email, &strgo.ByteCondition{
-			MinLength:        4,
-			MaxLength:        255,
-			OnlyContains:     append(strgo.AlphanumericByte, []byte{'_', '.', '@', '-', '+'}...),
-			MustBeFollowedBy: [2][]byte{{'_', '.', '@', '-', '+'}, strgo.AlphanumericByte},
-			MustContainsOnce: []byte{'@'},
+			MinLength:          4,
+			MaxLength:          255,
+			OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.', '@', '-', '+')),
+			MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.', '@', '-', '+'), strgo.AlphanumericSet}},
+			MustContainsOnce:   strgo.CharSetOf('@'),
 		})
 	}
 	err := validate("johndoe@email.com")
@@ -341,7 +609,7 @@ func TestPassword(t *testing.T) {
 		return strgo.Byte(password, &strgo.ByteCondition{
 			MinLength:                   6,
 			MaxLength:                   32,
-			OnlyContains:                strgo.CharsByte,
+			OnlyContains:                strgo.CharsSet,
 			AtLeastHaveUpperLetterCount: 1,
 			AtLeastHaveLowerLetterCount: 1,
 			AtLeastHaveNumberCount:      1,
@@ -359,3 +627,83 @@ func TestPassword(t *testing.T) {
 	err = validate("Johndoe123")
 	assert.NotNil(t, err)
 }
+
+func TestCompileByte(t *testing.T) {
+	v, err := strgo.CompileByte(&strgo.ByteCondition{
+		MinLength:          3,
+		OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+		MayContainsOnce:    strgo.CharSetOf('_', '.'),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.Validate("john_doe"))
+	assert.Nil(t, v.Validate("john.doe"))
+	assert.EqualError(t, v.Validate("jo"), "the string length cannot be less than 3")
+	assert.EqualError(t, v.Validate("john__doe"), "the char: _, must be surrounded with at least one of these characters: abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	assert.EqualError(t, v.Validate("john_do_e"), "the char: _, must be appeared once in the string")
+	assert.Nil(t, v.Validate("john_doe"))
+	_, err = strgo.CompileByte(nil)
+	assert.EqualError(t, err, "the condition is nil")
+	assert.Panics(t, func() { strgo.MustCompileByte(nil) })
+	assert.Nil(t, strgo.MustCompileByte(&strgo.ByteCondition{MaxLength: 3}).Validate("abc"))
+}
+
+func TestByte_MustBeSurroundedByNonASCII(t *testing.T) {
+	err := strgo.Byte("a_é", &strgo.ByteCondition{
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_'), strgo.AlphabeticSet}},
+	})
+	assert.EqualError(t, err, "the char: _, must be surrounded with at least one of these characters: abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+}
+
+func TestByte_SharedCondition(t *testing.T) {
+	cond := &strgo.ByteCondition{
+		MinLength:          3,
+		OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+		MayContainsOnce:    strgo.CharSetOf('_', '.'),
+		MustContainsOnce:   strgo.CharSetOf('j'),
+	}
+	v, err := strgo.CompileByte(cond)
+	assert.Nil(t, err)
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				assert.Nil(t, strgo.Byte("john_doe", cond))
+				assert.NotNil(t, strgo.Byte("john__doe", cond))
+				assert.Nil(t, v.Validate("john.doe"))
+				assert.NotNil(t, v.Validate("jo_hn_doe"))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestByte_ChangedCondition(t *testing.T) {
+	cond := &strgo.ByteCondition{
+		MaxLength:        3,
+		MustBeFollowedBy: [][2]strgo.CharSet{{strgo.CharSetOf('a'), strgo.CharSetOf('b')}},
+		CharCount:        map[byte]strgo.Range{'b': {Max: 1}},
+		PositionSets:     map[int]strgo.CharSet{0: strgo.CharSetOf('a')},
+	}
+	assert.Nil(t, strgo.Byte("ab", cond))
+	assert.NotNil(t, strgo.Byte("abab", cond))
+	cond.MaxLength = 4
+	assert.NotNil(t, strgo.Byte("abab", cond))
+	cond.CharCount['b'] = strgo.Range{Max: 2}
+	assert.Nil(t, strgo.Byte("abab", cond))
+	cond.MustBeFollowedBy[0][1] = strgo.CharSetOf('c')
+	assert.NotNil(t, strgo.Byte("abab", cond))
+	assert.Nil(t, strgo.Byte("acac", cond))
+	cond.PositionSets[-1] = strgo.CharSetOf('b')
+	assert.NotNil(t, strgo.Byte("acac", cond))
+	cond.MustBeFollowedBy[0][1] = strgo.CharSetOf('c', 'b')
+	assert.EqualError(t, strgo.Byte("aa", cond), "the char: a, must be followed with at least one of these characters: cb")
+	cond.MustBeFollowedBy[0][1] = strgo.CharSetOf('b', 'c')
+	assert.EqualError(t, strgo.Byte("aa", cond), "the char: a, must be followed with at least one of these characters: bc")
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, strgo.Byte("abc", &strgo.ByteCondition{MinLength: i % 4}))
+	}
+}
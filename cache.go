@@ -0,0 +1,276 @@
+package strgo
+
+import (
+	"math/bits"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxCachedConditions is how many conditions Byte keeps the validator of. The
+// conditions met after are compiled on every call.
+const maxCachedConditions = 64
+
+// byteCache holds the validators Byte compiled, by the encoding of their
+// condition, so a condition met again is only encoded and looked up. The map
+// is copied on write, so the lookups don't lock.
+var byteCache struct {
+	sync.Mutex
+	validators atomic.Value
+}
+
+// cachedByte returns the validator of the condition, compiling and caching it
+// the first time. It returns nil if the condition can't be cached.
+func cachedByte(cond *ByteCondition) (*ByteValidator, error) {
+	var buf [512]byte
+	key, ok := appendCondition(buf[:0], cond)
+	if !ok {
+		return nil, nil
+	}
+	validators, _ := byteCache.validators.Load().(map[string]*ByteValidator)
+	if v := validators[string(key)]; v != nil {
+		return v, nil
+	}
+
+	v, err := CompileByte(cond)
+	if err != nil {
+		return nil, err
+	}
+	byteCache.Lock()
+	defer byteCache.Unlock()
+	validators, _ = byteCache.validators.Load().(map[string]*ByteValidator)
+	if len(validators) < maxCachedConditions {
+		next := make(map[string]*ByteValidator, len(validators)+1)
+		for k, cached := range validators {
+			next[k] = cached
+		}
+		next[string(key)] = v
+		byteCache.validators.Store(next)
+	}
+
+	return v, nil
+}
+
+// orderSlots is how many slots orders has, and maxSlotOrders how many orders a
+// slot holds. The orders met after get their own string.
+const (
+	orderSlots    = 256
+	maxSlotOrders = 4
+)
+
+// orders interns the orders of the sets, so building a set again, like the sets
+// of a condition written in a loop, doesn't allocate. An order is kept in the
+// slot of the chars of its set, the slots are copied on write like byteCache.
+var orders struct {
+	sync.Mutex
+	slots [orderSlots]atomic.Value
+}
+
+// unions holds the unions of the sets already built, in the slot of their
+// chars like orders, so building a union again doesn't merge the orders again.
+var unions struct {
+	sync.Mutex
+	slots [orderSlots]atomic.Value
+}
+
+// union is a cached union, u is the union of s and o.
+type union struct {
+	s, o, u CharSet
+}
+
+// slotOf returns the slot of the chars of bits in orders and unions.
+func slotOf(bits [2]uint64) uint64 {
+	return (bits[0] ^ bits[1]*0x9e3779b97f4a7c15) * 0x9e3779b97f4a7c15 >> 56
+}
+
+// internOrder returns the order of the chars of bits as a string, the same
+// string for the same order.
+func internOrder[T string | []byte](bits [2]uint64, order T) string {
+	slot := &orders.slots[slotOf(bits)]
+	interned, _ := slot.Load().([]string)
+	for _, s := range interned {
+		if s == string(order) {
+			return s
+		}
+	}
+
+	s := strings.Clone(string(order))
+	orders.Lock()
+	defer orders.Unlock()
+	interned, _ = slot.Load().([]string)
+	if len(interned) < maxSlotOrders {
+		slot.Store(append(interned[:len(interned):len(interned)], s))
+	}
+
+	return s
+}
+
+// cachedUnion returns the union of s and o if it was built before, bits are the
+// chars of the union.
+func cachedUnion(s, o CharSet, bits [2]uint64) (CharSet, bool) {
+	cached, _ := unions.slots[slotOf(bits)].Load().([]union)
+	for i := range cached {
+		if cached[i].s == s && cached[i].o == o {
+			return cached[i].u, true
+		}
+	}
+
+	return CharSet{}, false
+}
+
+// cacheUnion keeps u as the union of s and o, if its slot isn't full.
+func cacheUnion(s, o, u CharSet) {
+	slot := &unions.slots[slotOf(u.bits)]
+	unions.Lock()
+	defer unions.Unlock()
+	cached, _ := slot.Load().([]union)
+	if len(cached) < maxSlotOrders {
+		slot.Store(append(cached[:len(cached):len(cached)], union{s: s.kept(), o: o.kept(), u: u.kept()}))
+	}
+}
+
+// maxKeyPositions is how many PositionSets a cached condition may have, they're
+// sorted on the stack to be encoded.
+const maxKeyPositions = 16
+
+// appendCondition appends the encoding of the condition to b, two conditions
+// have the same encoding only if they're equal. The fields that aren't zero are
+// appended, then the set of them, so the common conditions encode short. It
+// returns false if the condition has too many PositionSets to be encoded.
+func appendCondition(b []byte, cond *ByteCondition) ([]byte, bool) {
+	var fields uint32
+	field := 0
+	has := func(ok bool) bool {
+		if ok {
+			fields |= 1 << field
+		}
+		field++
+
+		return ok
+	}
+
+	for _, n := range [...]int{
+		cond.MinLength,
+		cond.MaxLength,
+		int(cond.LengthUnit),
+		cond.AtLeastHaveUpperLetterCount,
+		cond.AtLeastHaveLowerLetterCount,
+		cond.AtLeastHaveNumberCount,
+		cond.AtLeastHaveSpecialCharCount,
+		cond.MaxRepeatRun,
+		cond.MaxSequentialRun,
+		cond.MaxSameClassRun,
+	} {
+		if has(n != 0) {
+			b = appendInt(b, n)
+		}
+	}
+	// The sets that are only looked up are encoded without their order, the
+	// others list their chars in the errors.
+	for _, s := range [...]*CharSet{
+		&cond.OnlyContains,
+		&cond.OnlyContainsPrefix,
+		&cond.OnlyContainsSuffix,
+		&cond.MustNotContains,
+		&cond.MustNotContainsPrefix,
+		&cond.MustNotContainsSuffix,
+		&cond.MayContainsOnce,
+	} {
+		if has(!s.IsEmpty()) {
+			b = appendBits(b, *s)
+		}
+	}
+	for _, s := range [...]*CharSet{&cond.MustContains, &cond.MustContainsOnce} {
+		if has(!s.IsEmpty()) {
+			b = appendSet(b, *s)
+		}
+	}
+	for _, pairs := range [...][][2]CharSet{
+		cond.MustBeFollowedBy,
+		cond.MustBePrecededBy,
+		cond.MustBeSurroundedBy,
+		cond.MustNotBeFollowedBy,
+		cond.MustNotBePrecededBy,
+	} {
+		if has(len(pairs) > 0) {
+			b = appendInt(b, len(pairs))
+			for _, pair := range pairs {
+				b = appendSet(appendBits(b, pair[0]), pair[1])
+			}
+		}
+	}
+	if has(len(cond.Adjacency) > 0) {
+		b = appendInt(b, len(cond.Adjacency))
+		for _, r := range cond.Adjacency {
+			b = appendInt(appendSet(appendBits(b, r.Chars), r.Neighbors), int(r.Direction))
+			if r.Not {
+				b = append(b, 1)
+			} else {
+				b = append(b, 0)
+			}
+		}
+	}
+	if has(len(cond.ClassCounts) > 0) {
+		b = appendInt(b, len(cond.ClassCounts))
+		for _, cc := range cond.ClassCounts {
+			b = appendInt(appendInt(appendSet(b, cc.Set), cc.Min), cc.Max)
+		}
+	}
+	for _, sets := range [...][]CharSet{cond.PrefixSets, cond.SuffixSets} {
+		if has(len(sets) > 0) {
+			b = appendInt(b, len(sets))
+			for _, s := range sets {
+				b = appendSet(b, s)
+			}
+		}
+	}
+
+	// The map keys are encoded in order, the chars of CharCount through a set
+	// of them.
+	if has(len(cond.CharCount) > 0) {
+		var counted [4]uint64
+		for c := range cond.CharCount {
+			counted[c>>6] |= 1 << (c & 63)
+		}
+		b = appendInt(b, len(cond.CharCount))
+		for h, word := range counted {
+			for ; word != 0; word &= word - 1 {
+				c := byte(h<<6 | bits.TrailingZeros64(word))
+				b = appendInt(appendInt(append(b, c), cond.CharCount[c].Min), cond.CharCount[c].Max)
+			}
+		}
+	}
+	if has(len(cond.PositionSets) > 0) {
+		if len(cond.PositionSets) > maxKeyPositions {
+			return b, false
+		}
+		var indexes [maxKeyPositions]int
+		n := 0
+		for i := range cond.PositionSets {
+			indexes[n] = i
+			n++
+		}
+		sort.Ints(indexes[:n])
+		b = appendInt(b, n)
+		for _, i := range indexes[:n] {
+			b = appendSet(appendInt(b, i), cond.PositionSets[i])
+		}
+	}
+
+	return appendInt(b, int(fields)), true
+}
+
+func appendInt(b []byte, n int) []byte {
+	u := uint64(n)
+
+	return append(b, byte(u), byte(u>>8), byte(u>>16), byte(u>>24), byte(u>>32), byte(u>>40), byte(u>>48), byte(u>>56))
+}
+
+func appendBits(b []byte, s CharSet) []byte {
+	return appendInt(appendInt(b, int(s.bits[0])), int(s.bits[1]))
+}
+
+func appendSet(b []byte, s CharSet) []byte {
+	return append(appendInt(appendBits(b, s), len(s.order)), s.order...)
+}
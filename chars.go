@@ -1,48 +1,85 @@
 package strgo
 
-var AlphabeticByte = []byte{
+// The predefined sets of chars, they can be used as they are in the
+// ByteCondition fields, or combined with the CharSet methods.
+
+// AlphabeticSet is the set of the lower and upper letters.
+var AlphabeticSet = CharSetOf(
 	'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z',
 	'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z',
-}
+)
 
-var LowerAlphabeticByte = []byte{
+// LowerAlphabeticSet is the set of the lower letters.
+var LowerAlphabeticSet = CharSetOf(
 	'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z',
-}
+)
 
-var UpperAlphabeticByte = []byte{
+// UpperAlphabeticSet is the set of the upper letters.
+var UpperAlphabeticSet = CharSetOf(
 	'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z',
-}
+)
 
-var NumericByte = []byte{
+// NumericSet is the set of the digits.
+var NumericSet = CharSetOf(
 	'0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
-}
+)
 
-var AlphanumericByte = []byte{
+// AlphanumericSet is the set of the letters and the digits.
+var AlphanumericSet = CharSetOf(
 	'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z',
 	'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z',
 	'0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
-}
+)
 
-var SpecialCharsByte = []byte{
+// SpecialCharsSet is the set of the printable ASCII chars that are neither letters nor digits, the space included.
+var SpecialCharsSet = CharSetOf(
 	' ', '!', '"', '#', '$', '%', '&', '\'', '(', ')', '*', '+', ',', '-', '.', '/', ':', ';', '<', '=', '>', '?', '@',
 	'[', '\\', ']', '^', '_', '`', '{', '|', '}', '~',
-}
+)
 
-var QuotesByte = []byte{
+// QuotesSet is the set of the quotes.
+var QuotesSet = CharSetOf(
 	'"', '\'', '`',
-}
+)
 
-var BracketsByte = []byte{
+// BracketsSet is the set of the brackets.
+var BracketsSet = CharSetOf(
 	'(', ')', '[', ']', '{', '}',
-}
+)
 
-var OperatorsByte = []byte{
+// OperatorsSet is the set of the operators.
+var OperatorsSet = CharSetOf(
 	'!', '&', '+', '-', '<', '=', '>', '*',
-}
+)
 
-var CharsByte = []byte{
+// CharsSet is the set of the printable ASCII chars.
+var CharsSet = CharSetOf(
 	'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z',
 	'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z',
 	'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', ' ', '!', '"', '#', '$', '%', '&', '\'', '(', ')', '*', '+', ',', '-', '.', '/',
 	':', ';', '<', '=', '>', '?', '@', '[', '\\', ']', '^', '_', '`', '{', '|', '}', '~',
-}
+)
+
+// The sets under their former names.
+var (
+	// Deprecated: use AlphabeticSet.
+	AlphabeticByte = AlphabeticSet
+	// Deprecated: use LowerAlphabeticSet.
+	LowerAlphabeticByte = LowerAlphabeticSet
+	// Deprecated: use UpperAlphabeticSet.
+	UpperAlphabeticByte = UpperAlphabeticSet
+	// Deprecated: use NumericSet.
+	NumericByte = NumericSet
+	// Deprecated: use AlphanumericSet.
+	AlphanumericByte = AlphanumericSet
+	// Deprecated: use SpecialCharsSet.
+	SpecialCharsByte = SpecialCharsSet
+	// Deprecated: use QuotesSet.
+	QuotesByte = QuotesSet
+	// Deprecated: use BracketsSet.
+	BracketsByte = BracketsSet
+	// Deprecated: use OperatorsSet.
+	OperatorsByte = OperatorsSet
+	// Deprecated: use CharsSet.
+	CharsByte = CharsSet
+)
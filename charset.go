@@ -0,0 +1,361 @@
+package strgo
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// CharSet is a set of ASCII chars (0-127), stored as a 128-bit bitmap. Sets are
+// values, the methods return new sets and never change the receiver.
+//
+// A set remembers the order its chars were given in, Bytes, String and the
+// error messages list them in that order. Two sets with the same chars in a
+// different order aren't ==, Equal compares their chars.
+//
+// The ByteCondition set fields take a CharSet:
+//
+//	strgo.ByteCondition{
+//		OnlyContains: strgo.SpecialCharsSet.Minus(strgo.QuotesSet),
+//	}
+type CharSet struct {
+	bits [2]uint64
+	// order lists each char once, in the order they were given, it's empty
+	// when they were given in ascending order. add and remove only change the
+	// bits, so Bytes lists the chars of order still in the set, then the
+	// added ones.
+	order string
+}
+
+// asciiSet is the set of every ASCII char.
+var asciiSet = CharSet{bits: [2]uint64{^uint64(0), ^uint64(0)}}
+
+// CharSetOf returns the set of the chars, in their order. Chars above 127 are
+// ignored.
+func CharSetOf(chars ...byte) CharSet {
+	var s CharSet
+	last := -1
+	for _, c := range chars {
+		if int(c) <= last || c > asciiMaxDec {
+			return orderedSetOf(chars)
+		}
+		last = int(c)
+		s.add(c)
+	}
+
+	return s
+}
+
+// orderedSetOf returns the set of the chars, when they aren't all ASCII and in
+// ascending order.
+func orderedSetOf(chars []byte) CharSet {
+	var s CharSet
+	listed := true
+	for _, c := range chars {
+		if c > asciiMaxDec || s.Contains(c) {
+			listed = false
+			continue
+		}
+		s.add(c)
+	}
+	if listed {
+		s.order = internOrder(s.bits, chars)
+		return s
+	}
+
+	var buf [asciiMaxDec + 1]byte
+	order := buf[:0]
+	var added CharSet
+	for _, c := range chars {
+		if c <= asciiMaxDec && !added.Contains(c) {
+			added.add(c)
+			order = append(order, c)
+		}
+	}
+
+	return s.withOrder(order)
+}
+
+// withOrder returns the set with the order of its chars, order has each char
+// of the set once.
+func (s CharSet) withOrder(order []byte) CharSet {
+	for i := 1; i < len(order); i++ {
+		if order[i] < order[i-1] {
+			s.order = internOrder(s.bits, order)
+			return s
+		}
+	}
+	s.order = ""
+
+	return s
+}
+
+// CharRange returns the set of the chars from lo to hi, both included. Chars
+// above 127 are ignored.
+func CharRange(lo, hi byte) CharSet {
+	var s CharSet
+	for c := int(lo); c <= int(hi) && c <= asciiMaxDec; c++ {
+		s.add(byte(c))
+	}
+
+	return s
+}
+
+// ParseCharSet parses a set written like the inside of a regular expression
+// bracket expression, for example "a-zA-Z0-9_.". A hyphen between two chars is
+// a range, a hyphen at the start or the end is the hyphen itself. A backslash
+// escapes the next char, and \xHH is the char of the hex code HH. The chars
+// keep the order they're written in.
+func ParseCharSet(text string) (CharSet, error) {
+	var chars []byte
+
+	for i := 0; i < len(text); {
+		start := i
+		lo, next, err := parseSetChar(text, i)
+		if err != nil {
+			return CharSet{}, err
+		}
+		i = next
+		if i+1 < len(text) && text[i] == '-' {
+			hi, next, err := parseSetChar(text, i+1)
+			if err != nil {
+				return CharSet{}, err
+			}
+			if hi < lo {
+				return CharSet{}, errors.New("the range: " + text[start:next] + ", is reversed")
+			}
+			for c := lo; c < hi; c++ {
+				chars = append(chars, c)
+			}
+			chars = append(chars, hi)
+			i = next
+			continue
+		}
+		chars = append(chars, lo)
+	}
+
+	return CharSetOf(chars...), nil
+}
+
+// MustParseCharSet is like ParseCharSet but panics if the set can't be parsed.
+// It's meant for package-level variables.
+func MustParseCharSet(text string) CharSet {
+	s, err := ParseCharSet(text)
+	if err != nil {
+		panic("strgo: MustParseCharSet(" + strconv.Quote(text) + "): " + err.Error())
+	}
+
+	return s
+}
+
+// parseSetChar parses the char at i, and returns it with the index of the
+// next one.
+func parseSetChar(text string, i int) (byte, int, error) {
+	c := text[i]
+	if c > asciiMaxDec {
+		return 0, 0, errors.New("the char at offset: " + strconv.Itoa(i) + ", is not a valid ascii format")
+	}
+	if c != '\\' {
+		return c, i + 1, nil
+	}
+	if i+1 == len(text) {
+		return 0, 0, errors.New("the set cannot end with a backslash")
+	}
+	if text[i+1] != 'x' {
+		if text[i+1] > asciiMaxDec {
+			return 0, 0, errors.New("the char at offset: " + strconv.Itoa(i+1) + ", is not a valid ascii format")
+		}
+		return text[i+1], i + 2, nil
+	}
+	if i+4 > len(text) {
+		return 0, 0, errors.New("the escape at offset: " + strconv.Itoa(i) + ", must be written as \\xHH")
+	}
+	code, err := strconv.ParseUint(text[i+2:i+4], 16, 8)
+	if err != nil || code > asciiMaxDec {
+		return 0, 0, errors.New("the escape: " + text[i:i+4] + ", is not a valid ascii char")
+	}
+
+	return byte(code), i + 4, nil
+}
+
+// Union returns the chars that are in s or in o, the chars of s first.
+func (s CharSet) Union(o CharSet) CharSet {
+	if s.IsEmpty() {
+		return o
+	}
+	added := [2]uint64{o.bits[0] &^ s.bits[0], o.bits[1] &^ s.bits[1]}
+	if added == [2]uint64{} {
+		return s
+	}
+
+	u := CharSet{bits: [2]uint64{s.bits[0] | added[0], s.bits[1] | added[1]}}
+	if cached, ok := cachedUnion(s, o, u.bits); ok {
+		return cached
+	}
+
+	var buf, more [asciiMaxDec + 1]byte
+	order := s.appendBytes(buf[:0])
+	for _, c := range o.appendBytes(more[:0]) {
+		if added[c>>6]&(1<<(c&63)) != 0 {
+			order = append(order, c)
+		}
+	}
+	u = u.withOrder(order)
+	cacheUnion(s, o, u)
+
+	return u
+}
+
+// Intersect returns the chars that are in both s and o, in the order of s.
+func (s CharSet) Intersect(o CharSet) CharSet {
+	return s.filter([2]uint64{s.bits[0] & o.bits[0], s.bits[1] & o.bits[1]})
+}
+
+// Minus returns the chars of s that aren't in o, in the order of s.
+func (s CharSet) Minus(o CharSet) CharSet {
+	return s.filter([2]uint64{s.bits[0] &^ o.bits[0], s.bits[1] &^ o.bits[1]})
+}
+
+// filter returns the set of the chars of s that are in kept.
+func (s CharSet) filter(kept [2]uint64) CharSet {
+	if kept == s.bits {
+		return s
+	}
+	if s.order == "" {
+		return CharSet{bits: kept}
+	}
+
+	var buf [asciiMaxDec + 1]byte
+	order := buf[:0]
+	for i := 0; i < len(s.order); i++ {
+		if c := s.order[i]; kept[c>>6]&(1<<(c&63)) != 0 {
+			order = append(order, c)
+		}
+	}
+
+	return CharSet{bits: kept}.withOrder(order)
+}
+
+// Complement returns the ASCII chars that aren't in s, in ascending order.
+func (s CharSet) Complement() CharSet {
+	return CharSet{bits: [2]uint64{^s.bits[0], ^s.bits[1]}}
+}
+
+// Equal tells if s and o have the same chars, whatever their order.
+func (s CharSet) Equal(o CharSet) bool {
+	return s.bits == o.bits
+}
+
+// Contains tells if c is in the set.
+func (s CharSet) Contains(c byte) bool {
+	return c <= asciiMaxDec && s.bits[c>>6]&(1<<(c&63)) != 0
+}
+
+// members returns the set without its order, for the sets that are only
+// looked up.
+func (s CharSet) members() CharSet {
+	return CharSet{bits: s.bits}
+}
+
+// kept returns the set with its order interned, for the sets a validator keeps
+// for its messages, so compiling a condition doesn't move its sets to the heap.
+func (s CharSet) kept() CharSet {
+	k := CharSet{bits: s.bits}
+	if s.order != "" {
+		k.order = internOrder(s.bits, s.order)
+	}
+
+	return k
+}
+
+// add adds the ASCII char c to the set.
+func (s *CharSet) add(c byte) {
+	s.bits[c>>6] |= 1 << (c & 63)
+}
+
+// remove removes the ASCII char c from the set.
+func (s *CharSet) remove(c byte) {
+	s.bits[c>>6] &^= 1 << (c & 63)
+}
+
+// Len returns the number of chars in the set.
+func (s CharSet) Len() int {
+	return bits.OnesCount64(s.bits[0]) + bits.OnesCount64(s.bits[1])
+}
+
+// IsEmpty tells if the set has no char.
+func (s CharSet) IsEmpty() bool {
+	return s.bits[0] == 0 && s.bits[1] == 0
+}
+
+// Bytes returns the chars of the set in their order. It returns a new slice on
+// every call.
+func (s CharSet) Bytes() []byte {
+	return s.appendBytes(make([]byte, 0, s.Len()))
+}
+
+// appendBytes appends the chars of the set to b in their order.
+func (s CharSet) appendBytes(b []byte) []byte {
+	if len(s.order) == s.Len() {
+		return append(b, s.order...)
+	}
+
+	rest := s.bits
+	for i := 0; i < len(s.order); i++ {
+		if c := s.order[i]; rest[c>>6]&(1<<(c&63)) != 0 {
+			rest[c>>6] &^= 1 << (c & 63)
+			b = append(b, c)
+		}
+	}
+	for h, word := range rest {
+		for ; word != 0; word &= word - 1 {
+			b = append(b, byte(h<<6|bits.TrailingZeros64(word)))
+		}
+	}
+
+	return b
+}
+
+// String returns the set in the syntax of ParseCharSet, in the order of its
+// chars, with the runs of three ascending chars or more written as ranges, for
+// example "a-zA-Z0-9_".
+func (s CharSet) String() string {
+	var sb strings.Builder
+
+	b := s.Bytes()
+	for i := 0; i < len(b); i++ {
+		end := i
+		for end+1 < len(b) && b[end+1] == b[end]+1 {
+			end++
+		}
+		switch {
+		case end-i >= 2:
+			writeSetChar(&sb, b[i])
+			sb.WriteByte('-')
+			writeSetChar(&sb, b[end])
+		case end > i:
+			writeSetChar(&sb, b[i])
+			writeSetChar(&sb, b[end])
+		default:
+			writeSetChar(&sb, b[i])
+		}
+		i = end
+	}
+
+	return sb.String()
+}
+
+func writeSetChar(sb *strings.Builder, c byte) {
+	switch {
+	case c < ' ' || c == asciiMaxDec:
+		sb.WriteString(`\x`)
+		sb.WriteByte("0123456789abcdef"[c>>4])
+		sb.WriteByte("0123456789abcdef"[c&15])
+	case c == '\\' || c == '-' || c == '[' || c == ']':
+		sb.WriteByte('\\')
+		sb.WriteByte(c)
+	default:
+		sb.WriteByte(c)
+	}
+}
@@ -0,0 +1,84 @@
+package strgo_test
+
+import (
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCharSet_Algebra(t *testing.T) {
+	assert.Equal(t, strgo.AlphanumericSet, strgo.AlphabeticSet.Union(strgo.NumericSet))
+	assert.Equal(t, strgo.LowerAlphabeticSet, strgo.AlphabeticSet.Intersect(strgo.CharRange('a', 'z')))
+	assert.Equal(t, strgo.UpperAlphabeticSet, strgo.AlphabeticSet.Minus(strgo.LowerAlphabeticSet))
+	assert.Equal(t, strgo.CharsSet, strgo.AlphanumericSet.Union(strgo.SpecialCharsSet))
+	assert.Equal(t, 128, strgo.CharsSet.Len()+strgo.CharsSet.Complement().Len())
+	assert.True(t, strgo.CharsSet.Complement().Contains('\n'))
+	assert.False(t, strgo.CharsSet.Complement().Contains('a'))
+	assert.False(t, strgo.CharsSet.Contains(200))
+	assert.True(t, strgo.CharsSet.Intersect(strgo.CharsSet.Complement()).IsEmpty())
+	assert.True(t, strgo.CharSetOf('b', 'a').Equal(strgo.CharSetOf('a', 'b')))
+	assert.NotEqual(t, strgo.CharSetOf('b', 'a'), strgo.CharSetOf('a', 'b'))
+	assert.False(t, strgo.CharSetOf('a').Equal(strgo.CharSetOf('a', 'b')))
+
+	assert.Equal(t, strgo.AlphanumericSet, strgo.AlphanumericByte)
+
+	noQuotes := strgo.SpecialCharsSet.Minus(strgo.QuotesSet)
+	assert.Equal(t, strgo.SpecialCharsSet.Len()-strgo.QuotesSet.Len(), noQuotes.Len())
+	assert.False(t, noQuotes.Contains('"'))
+	assert.True(t, noQuotes.Contains('!'))
+}
+
+func TestCharSet_Bytes(t *testing.T) {
+	assert.Equal(t, []byte("0123456789"), strgo.NumericSet.Bytes())
+	assert.Equal(t, []byte("a_-"), strgo.CharSetOf('a', '_', '-', 'a', 200).Bytes())
+	assert.Equal(t, []byte("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"), strgo.AlphabeticSet.Bytes())
+	assert.Equal(t, []byte("ba-"), strgo.CharSetOf('b', '_', 'a').Minus(strgo.CharSetOf('_')).Union(strgo.CharSetOf('a', '-')).Bytes())
+	assert.Equal(t, []byte("db"), strgo.CharSetOf('d', 'c', 'b').Intersect(strgo.CharSetOf('b', 'd')).Bytes())
+	assert.Equal(t, []byte{}, strgo.CharSet{}.Bytes())
+	assert.Equal(t, strgo.CharRange('x', 'z'), strgo.CharSetOf('x', 'y', 'z'))
+	assert.Equal(t, strgo.CharSet{}, strgo.CharRange('z', 'a'))
+	assert.Equal(t, strgo.CharRange(120, 127), strgo.CharRange(120, 255))
+
+	err := strgo.Byte("john'doe", &strgo.ByteCondition{
+		OnlyContains: strgo.AlphanumericSet.Union(strgo.SpecialCharsSet.Minus(strgo.QuotesSet)),
+	})
+	assert.EqualError(t, err, "the string cannot contain char: '")
+}
+
+func TestParseCharSet(t *testing.T) {
+	tests := []struct {
+		text string
+		set  strgo.CharSet
+	}{
+		{"a-zA-Z0-9", strgo.AlphanumericSet},
+		{"a-zA-Z0-9_.", strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.'))},
+		{"-a", strgo.CharSetOf('-', 'a')},
+		{"a-", strgo.CharSetOf('a', '-')},
+		{`a\-z`, strgo.CharSetOf('a', '-', 'z')},
+		{`\\\]`, strgo.CharSetOf('\\', ']')},
+		{`\x00-\x1f\x7f`, strgo.CharsSet.Complement().Minus(strgo.CharSetOf(' '))},
+		{"", strgo.CharSet{}},
+	}
+	for _, tt := range tests {
+		set, err := strgo.ParseCharSet(tt.text)
+		assert.Nil(t, err, tt.text)
+		assert.Equal(t, tt.set, set, tt.text)
+	}
+
+	for _, text := range []string{"z-a", "é", `a\`, `\x`, `\xzz`, `\x80`} {
+		_, err := strgo.ParseCharSet(text)
+		assert.NotNil(t, err, text)
+	}
+	assert.Panics(t, func() { strgo.MustParseCharSet("z-a") })
+}
+
+func TestCharSet_String(t *testing.T) {
+	assert.Equal(t, "a-zA-Z0-9", strgo.AlphanumericSet.String())
+	assert.Equal(t, "0-9A-Za-z", strgo.CharRange('0', 'z').Intersect(strgo.AlphanumericSet).String())
+	assert.Equal(t, `_.\-`, strgo.CharSetOf('_', '.', '-').String())
+	assert.Equal(t, "ab", strgo.CharSetOf('a', 'b').String())
+	assert.Equal(t, `\x00-\x1f\x7f`, strgo.CharsSet.Complement().Minus(strgo.CharSetOf(' ')).String())
+	for _, set := range []strgo.CharSet{strgo.CharsSet, strgo.SpecialCharsSet, strgo.BracketsSet, strgo.CharsSet.Complement()} {
+		assert.Equal(t, set, strgo.MustParseCharSet(set.String()))
+	}
+}
@@ -0,0 +1,64 @@
+package strgo
+
+import (
+	"sort"
+	"strings"
+)
+
+// ValidationErrors is the list of violations returned by the ValidateAll
+// methods, ordered by their offset. Violations that aren't tied to a position
+// come last, in the order the rules were checked.
+type ValidationErrors []*ValidationError
+
+// Error returns the messages of all violations, one per line.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the violations, so errors.Is and errors.As look into each of them.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, v := range e {
+		errs[i] = v
+	}
+
+	return errs
+}
+
+// collector gathers the violations found by a validation. In the default
+// mode it keeps the first one and tells the validation to stop.
+type collector struct {
+	all  bool
+	errs ValidationErrors
+}
+
+// add records the violation, it returns true if the validation must stop.
+func (c *collector) add(e *ValidationError) bool {
+	c.errs = append(c.errs, e)
+
+	return !c.all
+}
+
+func (c *collector) err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	if !c.all {
+		return c.errs[0]
+	}
+
+	sort.SliceStable(c.errs, func(i, j int) bool {
+		a, b := c.errs[i].Offset, c.errs[j].Offset
+		if a < 0 {
+			return false
+		}
+		return b < 0 || a < b
+	})
+
+	return c.errs
+}
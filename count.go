@@ -0,0 +1,110 @@
+package strgo
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// Range is an inclusive range of occurrences, for CharCount and WordCount. A
+// zero Max means no upper limit, so Range{Min: 1} is "at least once" and
+// Range{Max: 2} is "at most twice".
+type Range struct {
+	Min int
+	Max int
+}
+
+func (r Range) valid() bool {
+	return r.Min >= 0 && r.Max >= 0 && (r.Max == 0 || r.Min <= r.Max)
+}
+
+// charCount is a compiled CharCount rule.
+type charCount struct {
+	char byte
+	Range
+}
+
+// compileCharCounts returns the CharCount rules sorted by char, so they're
+// reported in the same order on every run.
+func compileCharCounts(counts map[byte]Range) ([]charCount, error) {
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	r := make([]charCount, 0, len(counts))
+	for c, rng := range counts {
+		if c > asciiMaxDec {
+			return nil, errors.New("the char: " + string(rune(c)) + ", is not a valid ascii format")
+		}
+		if !rng.valid() {
+			return nil, errors.New("the count range of char: " + string(rune(c)) + ", is invalid")
+		}
+		r = append(r, charCount{char: c, Range: rng})
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].char < r[j].char
+	})
+
+	return r, nil
+}
+
+// wordCount is a compiled WordCount rule, id is the word in the automaton.
+type wordCount struct {
+	id int32
+	Range
+}
+
+// ClassCount limits how many chars of the Set the string has, like "at least 2
+// of !@#$" with {Set: strgo.CharSetOf('!', '@', '#', '$'), Min: 2}. A zero Max
+// means no upper limit.
+type ClassCount struct {
+	Set CharSet
+	Min int
+	Max int
+}
+
+// classCount is a compiled ClassCount, rule is RuleClassCount or the rule of
+// the AtLeastHave*Count shorthand it comes from.
+type classCount struct {
+	rule Rule
+	set  CharSet
+	Range
+}
+
+// compileClassCounts appends to r the AtLeastHave*Count shorthands of the
+// condition, then its ClassCounts.
+func compileClassCounts(cond *ByteCondition, r []classCount) ([]classCount, error) {
+	shorthands := [...]classCount{
+		{rule: RuleAtLeastHaveUpperLetterCount, set: UpperAlphabeticSet, Range: Range{Min: cond.AtLeastHaveUpperLetterCount}},
+		{rule: RuleAtLeastHaveLowerLetterCount, set: LowerAlphabeticSet, Range: Range{Min: cond.AtLeastHaveLowerLetterCount}},
+		{rule: RuleAtLeastHaveNumberCount, set: NumericSet, Range: Range{Min: cond.AtLeastHaveNumberCount}},
+		{rule: RuleAtLeastHaveSpecialCharCount, set: AlphanumericSet.Complement(), Range: Range{Min: cond.AtLeastHaveSpecialCharCount}},
+	}
+	n := len(cond.ClassCounts)
+	for _, sc := range shorthands {
+		if sc.Min > 0 {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if n > maxClassCounts {
+		return nil, errors.New("the class counts must not be more than " + strconv.Itoa(maxClassCounts))
+	}
+
+	for _, sc := range shorthands {
+		if sc.Min > 0 {
+			r = append(r, sc)
+		}
+	}
+	for i, cc := range cond.ClassCounts {
+		rng := Range{Min: cc.Min, Max: cc.Max}
+		if !rng.valid() {
+			return nil, errors.New("the count range of class count: " + strconv.Itoa(i) + ", is invalid")
+		}
+		r = append(r, classCount{rule: RuleClassCount, set: cc.Set.kept(), Range: rng})
+	}
+
+	return r, nil
+}
@@ -0,0 +1,180 @@
+package strgo
+
+import (
+	"net"
+	"strings"
+)
+
+// EmailOptions are the options of Email. The zero value validates a dot-atom
+// local part and a domain name with a TLD.
+type EmailOptions struct {
+	// Local is the condition of the unquoted local part. If nil, the local part
+	// is an RFC 5322 dot-atom of up to 64 chars: atext chars, where a period
+	// must be surrounded by atext chars.
+	Local *ByteCondition
+	// AllowQuotedLocal allows an RFC 5322 quoted local part, like "john doe"@email.com.
+	AllowQuotedLocal bool
+	// AllowIPLiteral allows an IP address domain, like john@[127.0.0.1] or
+	// john@[IPv6:::1].
+	AllowIPLiteral bool
+	// AllowNoTLD allows a domain without a top-level domain, like john@localhost.
+	AllowNoTLD bool
+}
+
+// EmailPart is the part of the email address that failed the validation.
+type EmailPart int
+
+const (
+	EmailPartAddress EmailPart = iota + 1
+	EmailPartLocal
+	EmailPartDomain
+)
+
+// String returns the name of the part.
+func (p EmailPart) String() string {
+	switch p {
+	case EmailPartLocal:
+		return "local part"
+	case EmailPartDomain:
+		return "domain"
+	}
+
+	return "address"
+}
+
+// EmailError is returned by Email, it tells which part of the address failed.
+// It unwraps to the error of that part, which is a *ValidationError for the
+// byte condition rules, or a *LabelError for the domain labels.
+type EmailError struct {
+	Part EmailPart
+	Err  error
+}
+
+func (e *EmailError) Error() string {
+	return "the email " + e.Part.String() + " is invalid: " + e.Err.Error()
+}
+
+func (e *EmailError) Unwrap() error {
+	return e.Err
+}
+
+const (
+	emailMaxLength      = 254
+	emailLocalMaxLength = 64
+)
+
+// atextByte are the chars of an RFC 5322 atom.
+var atextByte = AlphanumericSet.Union(CharSetOf('!', '#', '$', '%', '&', '\'', '*', '+', '-', '/', '=', '?', '^', '_', '`', '{', '|', '}', '~'))
+
+var dotAtomValidator = MustCompileByte(&ByteCondition{
+	MaxLength:          emailLocalMaxLength,
+	OnlyContains:       atextByte.Union(CharSetOf('.')),
+	MustBeSurroundedBy: [][2]CharSet{{CharSetOf('.'), atextByte}},
+})
+
+// Email validates an email address. The address is split on its last @, the
+// local part is validated with the Local condition, or as a quoted string, and
+// the domain with Hostname, or as an IP literal.
+// If the address is invalid, it will return an *EmailError.
+func Email(text string, opts EmailOptions) error {
+	if text == "" {
+		return &EmailError{Part: EmailPartAddress, Err: &ValidationError{Rule: RuleEmpty, Offset: -1}}
+	}
+	if len(text) > emailMaxLength {
+		return &EmailError{Part: EmailPartAddress, Err: &ValidationError{Rule: RuleMaxLength, Offset: -1, Limit: emailMaxLength, Count: len(text), Unit: LengthBytes}}
+	}
+
+	at := strings.LastIndexByte(text, '@')
+	if at < 0 {
+		return &EmailError{Part: EmailPartAddress, Err: &ValidationError{Rule: RuleMustContainsOnce, Char: '@', Offset: -1, Limit: 1}}
+	}
+	local, domain := text[:at], text[at+1:]
+	quoted := opts.AllowQuotedLocal && strings.HasPrefix(local, `"`)
+	if !quoted {
+		if i := strings.IndexByte(local, '@'); i >= 0 {
+			return &EmailError{Part: EmailPartAddress, Err: &ValidationError{Rule: RuleMustContainsOnce, Char: '@', Offset: at, Limit: 1, Count: strings.Count(text, "@")}}
+		}
+	}
+
+	if err := validateLocal(local, quoted, opts.Local); err != nil {
+		return &EmailError{Part: EmailPartLocal, Err: err}
+	}
+	if err := validateEmailDomain(domain, opts); err != nil {
+		return &EmailError{Part: EmailPartDomain, Err: err}
+	}
+
+	return nil
+}
+
+func validateLocal(local string, quoted bool, cond *ByteCondition) error {
+	if quoted {
+		return validateQuoted(local)
+	}
+	if cond == nil {
+		return dotAtomValidator.Validate(local)
+	}
+
+	return Byte(local, cond)
+}
+
+// validateQuoted validates an RFC 5322 quoted string: printable ASCII chars and
+// spaces between double quotes, where a double quote or a backslash must be
+// escaped by a backslash.
+func validateQuoted(local string) error {
+	if len(local) > emailLocalMaxLength {
+		return &ValidationError{Rule: RuleMaxLength, Offset: -1, Limit: emailLocalMaxLength, Count: len(local), Unit: LengthBytes}
+	}
+	if len(local) < 2 || local[len(local)-1] != '"' {
+		return &ValidationError{Rule: RuleQuotedLocal, Char: '"', Offset: -1}
+	}
+
+	for i := 1; i < len(local)-1; i++ {
+		c := local[i]
+		if c == '\\' {
+			i++
+			if i == len(local)-1 {
+				return &ValidationError{Rule: RuleQuotedLocal, Char: '\\', Offset: i - 1}
+			}
+			c = local[i]
+		} else if c == '"' {
+			return &ValidationError{Rule: RuleOnlyContains, Char: rune(c), Offset: i}
+		}
+		if c > asciiMaxDec {
+			return &ValidationError{Rule: RuleNotASCII, Char: rune(c), Offset: i}
+		}
+		if c < ' ' || c == asciiMaxDec {
+			return &ValidationError{Rule: RuleOnlyContains, Char: rune(c), Offset: i}
+		}
+	}
+
+	return nil
+}
+
+func validateEmailDomain(domain string, opts EmailOptions) error {
+	if opts.AllowIPLiteral && strings.HasPrefix(domain, "[") {
+		return validateIPLiteral(domain)
+	}
+
+	return Hostname(domain, HostnameOptions{RequireTLD: !opts.AllowNoTLD})
+}
+
+// validateIPLiteral validates an RFC 5321 address literal, an IPv4 address or
+// an IPv6 address prefixed by "IPv6:", between square brackets.
+func validateIPLiteral(domain string) error {
+	if !strings.HasSuffix(domain, "]") {
+		return &ValidationError{Rule: RuleIPLiteral, Char: ']', Offset: -1}
+	}
+
+	addr := domain[1 : len(domain)-1]
+	if len(addr) > 5 && strings.EqualFold(addr[:5], "IPv6:") {
+		if ip := net.ParseIP(addr[5:]); ip != nil && strings.Contains(addr[5:], ":") {
+			return nil
+		}
+		return &ValidationError{Rule: RuleIPLiteral, Word: addr, Offset: 1}
+	}
+	if ip := net.ParseIP(addr); ip != nil && ip.To4() != nil && !strings.Contains(addr, ":") {
+		return nil
+	}
+
+	return &ValidationError{Rule: RuleIPLiteral, Word: addr, Offset: 1}
+}
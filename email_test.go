@@ -0,0 +1,103 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEmail_Options(t *testing.T) {
+	tests := []struct {
+		text  string
+		opts  strgo.EmailOptions
+		valid bool
+	}{
+		{"johndoe@email.com", strgo.EmailOptions{}, true},
+		{"john.doe+tag@mail.email.co.id", strgo.EmailOptions{}, true},
+		{"john!#$%&'*+/=?^_`{|}~-doe@email.com", strgo.EmailOptions{}, true},
+		{"johndoe@email", strgo.EmailOptions{}, false},
+		{"johndoe@email", strgo.EmailOptions{AllowNoTLD: true}, true},
+		{"johndoe@email.123", strgo.EmailOptions{}, false},
+		{"johndoe@-email.com", strgo.EmailOptions{}, false},
+		{"johndoe@email-.com", strgo.EmailOptions{}, false},
+		{"johndoe@email..com", strgo.EmailOptions{}, false},
+		{"johndoe@email.com.", strgo.EmailOptions{}, false},
+		{"johndoe@email_1.com", strgo.EmailOptions{}, false},
+		{"johndoe@" + strings.Repeat("a", 64) + ".com", strgo.EmailOptions{}, false},
+		{".johndoe@email.com", strgo.EmailOptions{}, false},
+		{"john..doe@email.com", strgo.EmailOptions{}, false},
+		{"john doe@email.com", strgo.EmailOptions{}, false},
+		{strings.Repeat("a", 65) + "@email.com", strgo.EmailOptions{}, false},
+		{"johndoe", strgo.EmailOptions{}, false},
+		{"john@doe@email.com", strgo.EmailOptions{}, false},
+		{"@email.com", strgo.EmailOptions{}, false},
+		{"johndoe@", strgo.EmailOptions{}, false},
+		{`"john doe"@email.com`, strgo.EmailOptions{}, false},
+		{`"john doe"@email.com`, strgo.EmailOptions{AllowQuotedLocal: true}, true},
+		{`"john@doe"@email.com`, strgo.EmailOptions{AllowQuotedLocal: true}, true},
+		{`"john\"doe"@email.com`, strgo.EmailOptions{AllowQuotedLocal: true}, true},
+		{`"john"doe"@email.com`, strgo.EmailOptions{AllowQuotedLocal: true}, false},
+		{`"johndoe@email.com`, strgo.EmailOptions{AllowQuotedLocal: true}, false},
+		{`"john\"@email.com`, strgo.EmailOptions{AllowQuotedLocal: true}, false},
+		{"johndoe@[127.0.0.1]", strgo.EmailOptions{}, false},
+		{"johndoe@[127.0.0.1]", strgo.EmailOptions{AllowIPLiteral: true}, true},
+		{"johndoe@[IPv6:2001:db8::1]", strgo.EmailOptions{AllowIPLiteral: true}, true},
+		{"johndoe@[2001:db8::1]", strgo.EmailOptions{AllowIPLiteral: true}, false},
+		{"johndoe@[IPv6:127.0.0.1]", strgo.EmailOptions{AllowIPLiteral: true}, false},
+		{"johndoe@[127.0.0.256]", strgo.EmailOptions{AllowIPLiteral: true}, false},
+		{"johndoe@[127.0.0.1", strgo.EmailOptions{AllowIPLiteral: true}, false},
+		{"john_doe@email.com", strgo.EmailOptions{Local: strgo.EmailLocalCondition()}, true},
+		{"john!doe@email.com", strgo.EmailOptions{Local: strgo.EmailLocalCondition()}, false},
+	}
+	for _, tt := range tests {
+		err := strgo.Email(tt.text, tt.opts)
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+}
+
+func TestEmail_Error(t *testing.T) {
+	err := strgo.Email("john doe@email.com", strgo.EmailOptions{})
+	var eerr *strgo.EmailError
+	assert.True(t, errors.As(err, &eerr))
+	assert.Equal(t, strgo.EmailPartLocal, eerr.Part)
+	assert.True(t, errors.Is(err, strgo.ErrOnlyContains))
+	assert.EqualError(t, err, "the email local part is invalid: the string cannot contain char:  ")
+	err = strgo.Email("johndoe@email.c-", strgo.EmailOptions{})
+	assert.True(t, errors.As(err, &eerr))
+	assert.Equal(t, strgo.EmailPartDomain, eerr.Part)
+	var lerr *strgo.LabelError
+	assert.True(t, errors.As(err, &lerr))
+	assert.Equal(t, 1, lerr.Index)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleMustNotContainsSuffix, verr.Rule)
+	assert.Equal(t, 1, verr.Offset)
+	err = strgo.Email("johndoe@email", strgo.EmailOptions{})
+	assert.EqualError(t, err, "the email domain is invalid: the hostname must have a top-level domain")
+	err = strgo.Email("john@doe@email.com", strgo.EmailOptions{})
+	assert.True(t, errors.As(err, &eerr))
+	assert.Equal(t, strgo.EmailPartAddress, eerr.Part)
+	assert.True(t, errors.Is(err, strgo.ErrMustContainsOnce))
+
+	quoted := strgo.EmailOptions{AllowQuotedLocal: true, AllowIPLiteral: true}
+	err = strgo.Email(`"john doe@email.com`, quoted)
+	assert.True(t, errors.Is(err, strgo.ErrQuotedLocal))
+	assert.EqualError(t, err, "the email local part is invalid: the quoted string must be closed with a double quote")
+	err = strgo.Email(`"john\"@email.com`, quoted)
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleQuotedLocal, verr.Rule)
+	assert.Equal(t, 5, verr.Offset)
+	assert.EqualError(t, err, "the email local part is invalid: the quoted string cannot end with a backslash")
+	err = strgo.Email("john@[127.0.0.1", quoted)
+	assert.True(t, errors.Is(err, strgo.ErrIPLiteral))
+	assert.EqualError(t, err, "the email domain is invalid: the ip literal must be closed with a square bracket")
+	err = strgo.Email("john@[127.0.0.256]", quoted)
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleIPLiteral, verr.Rule)
+	assert.Equal(t, "127.0.0.256", verr.Word)
+	assert.EqualError(t, err, "the email domain is invalid: the ip literal is not a valid ipv4 address: 127.0.0.256")
+	err = strgo.Email("john@[IPv6:127.0.0.1]", quoted)
+	assert.EqualError(t, err, "the email domain is invalid: the ip literal is not a valid ipv6 address: 127.0.0.1")
+}
@@ -0,0 +1,362 @@
+package strgo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Rule identifies the condition rule that a string violated.
+type Rule int
+
+const (
+	RuleEmpty Rule = iota + 1
+	RuleMinLength
+	RuleMaxLength
+	RuleNotASCII
+	RuleOnlyContains
+	RuleOnlyContainsPrefix
+	RuleOnlyContainsSuffix
+	RuleMustContains
+	RuleMustContainsOnce
+	RuleMustNotContains
+	RuleMustNotContainsPrefix
+	RuleMustNotContainsSuffix
+	RuleMustBeFollowedBy
+	RuleMayContainsOnce
+	RuleAtLeastHaveUpperLetterCount
+	RuleAtLeastHaveLowerLetterCount
+	RuleAtLeastHaveNumberCount
+	RuleAtLeastHaveSpecialCharCount
+	RuleOnlyContainsPrefixWord
+	RuleOnlyContainsSuffixWord
+	RuleMustContainsWord
+	RuleMustContainsWordOnce
+	RuleMustNotContainsWord
+	RuleMustNotContainsPrefixWord
+	RuleMustNotContainsSuffixWord
+	RuleMayContainsWordOnce
+	RuleNotUTF8
+	RuleCharCount
+	RuleWordCount
+	RuleClassCount
+	RuleMaxRepeatRun
+	RuleMaxSequentialRun
+	RuleMaxSameClassRun
+	RuleMustBePrecededBy
+	RuleMustBeSurroundedBy
+	RuleMustNotBeFollowedBy
+	RuleMustNotBePrecededBy
+	RuleAdjacency
+	RulePositionSets
+	RuleMinSegments
+	RuleMaxSegments
+	RuleRequireTLD
+	RuleQuotedLocal
+	RuleIPLiteral
+	RulePunycode
+)
+
+// Sentinel errors, one per Rule. A *ValidationError unwraps to the sentinel of
+// its rule, so errors.Is(err, strgo.ErrOnlyContains) reports whether err was
+// caused by the OnlyContains rule.
+var (
+	ErrEmpty                       = errors.New("strgo: Empty")
+	ErrMinLength                   = errors.New("strgo: MinLength")
+	ErrMaxLength                   = errors.New("strgo: MaxLength")
+	ErrNotASCII                    = errors.New("strgo: NotASCII")
+	ErrOnlyContains                = errors.New("strgo: OnlyContains")
+	ErrOnlyContainsPrefix          = errors.New("strgo: OnlyContainsPrefix")
+	ErrOnlyContainsSuffix          = errors.New("strgo: OnlyContainsSuffix")
+	ErrMustContains                = errors.New("strgo: MustContains")
+	ErrMustContainsOnce            = errors.New("strgo: MustContainsOnce")
+	ErrMustNotContains             = errors.New("strgo: MustNotContains")
+	ErrMustNotContainsPrefix       = errors.New("strgo: MustNotContainsPrefix")
+	ErrMustNotContainsSuffix       = errors.New("strgo: MustNotContainsSuffix")
+	ErrMustBeFollowedBy            = errors.New("strgo: MustBeFollowedBy")
+	ErrMayContainsOnce             = errors.New("strgo: MayContainsOnce")
+	ErrAtLeastHaveUpperLetterCount = errors.New("strgo: AtLeastHaveUpperLetterCount")
+	ErrAtLeastHaveLowerLetterCount = errors.New("strgo: AtLeastHaveLowerLetterCount")
+	ErrAtLeastHaveNumberCount      = errors.New("strgo: AtLeastHaveNumberCount")
+	ErrAtLeastHaveSpecialCharCount = errors.New("strgo: AtLeastHaveSpecialCharCount")
+	ErrOnlyContainsPrefixWord      = errors.New("strgo: OnlyContainsPrefixWord")
+	ErrOnlyContainsSuffixWord      = errors.New("strgo: OnlyContainsSuffixWord")
+	ErrMustContainsWord            = errors.New("strgo: MustContainsWord")
+	ErrMustContainsWordOnce        = errors.New("strgo: MustContainsWordOnce")
+	ErrMustNotContainsWord         = errors.New("strgo: MustNotContainsWord")
+	ErrMustNotContainsPrefixWord   = errors.New("strgo: MustNotContainsPrefixWord")
+	ErrMustNotContainsSuffixWord   = errors.New("strgo: MustNotContainsSuffixWord")
+	ErrMayContainsWordOnce         = errors.New("strgo: MayContainsWordOnce")
+	ErrNotUTF8                     = errors.New("strgo: NotUTF8")
+	ErrCharCount                   = errors.New("strgo: CharCount")
+	ErrWordCount                   = errors.New("strgo: WordCount")
+	ErrClassCount                  = errors.New("strgo: ClassCount")
+	ErrMaxRepeatRun                = errors.New("strgo: MaxRepeatRun")
+	ErrMaxSequentialRun            = errors.New("strgo: MaxSequentialRun")
+	ErrMaxSameClassRun             = errors.New("strgo: MaxSameClassRun")
+	ErrMustBePrecededBy            = errors.New("strgo: MustBePrecededBy")
+	ErrMustBeSurroundedBy          = errors.New("strgo: MustBeSurroundedBy")
+	ErrMustNotBeFollowedBy         = errors.New("strgo: MustNotBeFollowedBy")
+	ErrMustNotBePrecededBy         = errors.New("strgo: MustNotBePrecededBy")
+	ErrAdjacency                   = errors.New("strgo: Adjacency")
+	ErrPositionSets                = errors.New("strgo: PositionSets")
+	ErrMinSegments                 = errors.New("strgo: MinSegments")
+	ErrMaxSegments                 = errors.New("strgo: MaxSegments")
+	ErrRequireTLD                  = errors.New("strgo: RequireTLD")
+	ErrQuotedLocal                 = errors.New("strgo: QuotedLocal")
+	ErrIPLiteral                   = errors.New("strgo: IPLiteral")
+	ErrPunycode                    = errors.New("strgo: Punycode")
+)
+
+var ruleErrors = [...]error{
+	RuleEmpty:                       ErrEmpty,
+	RuleMinLength:                   ErrMinLength,
+	RuleMaxLength:                   ErrMaxLength,
+	RuleNotASCII:                    ErrNotASCII,
+	RuleOnlyContains:                ErrOnlyContains,
+	RuleOnlyContainsPrefix:          ErrOnlyContainsPrefix,
+	RuleOnlyContainsSuffix:          ErrOnlyContainsSuffix,
+	RuleMustContains:                ErrMustContains,
+	RuleMustContainsOnce:            ErrMustContainsOnce,
+	RuleMustNotContains:             ErrMustNotContains,
+	RuleMustNotContainsPrefix:       ErrMustNotContainsPrefix,
+	RuleMustNotContainsSuffix:       ErrMustNotContainsSuffix,
+	RuleMustBeFollowedBy:            ErrMustBeFollowedBy,
+	RuleMayContainsOnce:             ErrMayContainsOnce,
+	RuleAtLeastHaveUpperLetterCount: ErrAtLeastHaveUpperLetterCount,
+	RuleAtLeastHaveLowerLetterCount: ErrAtLeastHaveLowerLetterCount,
+	RuleAtLeastHaveNumberCount:      ErrAtLeastHaveNumberCount,
+	RuleAtLeastHaveSpecialCharCount: ErrAtLeastHaveSpecialCharCount,
+	RuleOnlyContainsPrefixWord:      ErrOnlyContainsPrefixWord,
+	RuleOnlyContainsSuffixWord:      ErrOnlyContainsSuffixWord,
+	RuleMustContainsWord:            ErrMustContainsWord,
+	RuleMustContainsWordOnce:        ErrMustContainsWordOnce,
+	RuleMustNotContainsWord:         ErrMustNotContainsWord,
+	RuleMustNotContainsPrefixWord:   ErrMustNotContainsPrefixWord,
+	RuleMustNotContainsSuffixWord:   ErrMustNotContainsSuffixWord,
+	RuleMayContainsWordOnce:         ErrMayContainsWordOnce,
+	RuleNotUTF8:                     ErrNotUTF8,
+	RuleCharCount:                   ErrCharCount,
+	RuleWordCount:                   ErrWordCount,
+	RuleClassCount:                  ErrClassCount,
+	RuleMaxRepeatRun:                ErrMaxRepeatRun,
+	RuleMaxSequentialRun:            ErrMaxSequentialRun,
+	RuleMaxSameClassRun:             ErrMaxSameClassRun,
+	RuleMustBePrecededBy:            ErrMustBePrecededBy,
+	RuleMustBeSurroundedBy:          ErrMustBeSurroundedBy,
+	RuleMustNotBeFollowedBy:         ErrMustNotBeFollowedBy,
+	RuleMustNotBePrecededBy:         ErrMustNotBePrecededBy,
+	RuleAdjacency:                   ErrAdjacency,
+	RulePositionSets:                ErrPositionSets,
+	RuleMinSegments:                 ErrMinSegments,
+	RuleMaxSegments:                 ErrMaxSegments,
+	RuleRequireTLD:                  ErrRequireTLD,
+	RuleQuotedLocal:                 ErrQuotedLocal,
+	RuleIPLiteral:                   ErrIPLiteral,
+	RulePunycode:                    ErrPunycode,
+}
+
+// Err returns the sentinel error of the rule.
+func (r Rule) Err() error {
+	if r > 0 && int(r) < len(ruleErrors) {
+		return ruleErrors[r]
+	}
+
+	return nil
+}
+
+// String returns the name of the rule, the same as its condition field.
+func (r Rule) String() string {
+	if err := r.Err(); err != nil {
+		return err.Error()[len("strgo: "):]
+	}
+
+	return "Rule(" + strconv.Itoa(int(r)) + ")"
+}
+
+// ValidationError describes a rule that the string violated.
+// Its message is the same as the one returned before the error was typed.
+type ValidationError struct {
+	// Rule is the violated rule.
+	Rule Rule
+	// Char is the offending char, zero if the rule isn't about a char.
+	Char rune
+	// Word is the offending word, empty if the rule isn't about a word.
+	Word string
+	// Offset is the byte offset of the offending char or word in the string,
+	// -1 if the violation isn't tied to a position.
+	Offset int
+	// Length is the byte length of the offending word in the string, from
+	// Offset. It differs from len(Word) when the string is folded or
+	// normalized before the words are matched.
+	Length int
+	// Index is the index of the violated rule pair in its condition field,
+	// for the adjacency rules, or the index of the char, negative from the
+	// end, for the position rules.
+	Index int
+	// Limit is the configured limit of the rule (length, count), zero if the
+	// rule has none.
+	Limit int
+	// Count is how many times the char, word or class was found, for the
+	// rules that count.
+	Count int
+	// Unit is the unit of Limit and Count for the length rules.
+	Unit LengthUnit
+	// Expected lists the characters the rule expected, in the order of their
+	// set, for the adjacency rules, ClassCounts and PositionSets.
+	Expected string
+}
+
+// Error returns the human-readable message of the violation.
+func (e *ValidationError) Error() string {
+	switch e.Rule {
+	case RuleEmpty:
+		return "the string is empty"
+	case RuleMinLength:
+		return "the string length cannot be less than " + strconv.Itoa(e.Limit) + e.unitSuffix()
+	case RuleMaxLength:
+		return "the string length cannot be more than " + strconv.Itoa(e.Limit) + e.unitSuffix()
+	case RuleNotASCII:
+		return "the char: " + string(e.Char) + ", is not a valid ascii format"
+	case RuleOnlyContains:
+		return "the string cannot contain char: " + string(e.Char)
+	case RuleOnlyContainsPrefix:
+		return "the string cannot contain prefix char: " + string(e.Char)
+	case RuleOnlyContainsSuffix:
+		return "the string cannot contain suffix char: " + string(e.Char)
+	case RuleMustContains:
+		return "the string must contain char: " + string(e.Char)
+	case RuleMustContainsOnce:
+		if e.Count == 0 {
+			return "the string must contain char: " + string(e.Char)
+		}
+		return "the char: " + string(e.Char) + ", must be appeared once in the string"
+	case RuleMustNotContains:
+		return "the string must not contain char: " + string(e.Char)
+	case RuleMustNotContainsPrefix:
+		return "the string must not contain prefix: " + string(e.Char)
+	case RuleMustNotContainsSuffix:
+		return "the string must not contain suffix: " + string(e.Char)
+	case RuleMustBeFollowedBy:
+		return e.adjacencyMessage("must be followed with at least one of")
+	case RuleMustBePrecededBy:
+		return e.adjacencyMessage("must be preceded with at least one of")
+	case RuleMustBeSurroundedBy:
+		return e.adjacencyMessage("must be surrounded with at least one of")
+	case RuleMustNotBeFollowedBy:
+		return e.adjacencyMessage("must not be followed with any of")
+	case RuleMustNotBePrecededBy:
+		return e.adjacencyMessage("must not be preceded with any of")
+	case RuleAdjacency:
+		return "the char: " + string(e.Char) + ", violates the adjacency rule at index: " + strconv.Itoa(e.Index)
+	case RulePositionSets:
+		return "the char: " + string(e.Char) + ", at index: " + strconv.Itoa(e.Index) + ", must be one of these characters: " + e.Expected
+	case RuleMayContainsOnce:
+		return "the char: " + string(e.Char) + ", must be appeared once in the string"
+	case RuleAtLeastHaveUpperLetterCount:
+		return "the string must have at least " + strconv.Itoa(e.Limit) + " upper case letter(s)"
+	case RuleAtLeastHaveLowerLetterCount:
+		return "the string must have at least " + strconv.Itoa(e.Limit) + " lower case letter(s)"
+	case RuleAtLeastHaveNumberCount:
+		return "the string must have at least " + strconv.Itoa(e.Limit) + " number(s)"
+	case RuleAtLeastHaveSpecialCharCount:
+		return "the string must have at least " + strconv.Itoa(e.Limit) + " special char(s)"
+	case RuleOnlyContainsPrefixWord:
+		return "the string prefix doesn't match with the given prefix words"
+	case RuleOnlyContainsSuffixWord:
+		return "the string suffix doesn't match with the given suffix words"
+	case RuleMustContainsWord:
+		return "the string must contain word: " + e.Word
+	case RuleMustContainsWordOnce:
+		return "the string must contain word: " + e.Word + ", and it must be appeared once in the string"
+	case RuleMustNotContainsWord:
+		return "the string must not contain word: " + e.Word
+	case RuleMustNotContainsPrefixWord:
+		return "the string must not contain prefix word: " + e.Word
+	case RuleMustNotContainsSuffixWord:
+		return "the string must not contain suffix word: " + e.Word
+	case RuleMayContainsWordOnce:
+		return "the word: " + e.Word + ", must be appeared once in the string"
+	case RuleNotUTF8:
+		return "the string has an invalid utf-8 char at offset: " + strconv.Itoa(e.Offset)
+	case RuleCharCount:
+		return "the char: " + string(e.Char) + ", must be appeared " + e.countLimit() + " in the string"
+	case RuleWordCount:
+		return "the word: " + e.Word + ", must be appeared " + e.countLimit() + " in the string"
+	case RuleClassCount:
+		if e.Count < e.Limit {
+			return "the string must have at least " + strconv.Itoa(e.Limit) + " char(s) of: " + e.Expected
+		}
+		return "the string must have at most " + strconv.Itoa(e.Limit) + " char(s) of: " + e.Expected
+	case RuleMaxRepeatRun:
+		return "the char: " + string(e.Char) + ", must not be repeated more than " + strconv.Itoa(e.Limit) + " time(s) in a row"
+	case RuleMaxSequentialRun:
+		return "the char: " + string(e.Char) + ", must not continue a sequence of more than " + strconv.Itoa(e.Limit) + " char(s)"
+	case RuleMaxSameClassRun:
+		return "the char: " + string(e.Char) + ", must not continue a run of more than " + strconv.Itoa(e.Limit) + " char(s) of the same class"
+	case RuleMinSegments:
+		return "the string segment count cannot be less than " + strconv.Itoa(e.Limit)
+	case RuleMaxSegments:
+		return "the string segment count cannot be more than " + strconv.Itoa(e.Limit)
+	case RuleRequireTLD:
+		if e.Word == "" {
+			return "the hostname must have a top-level domain"
+		}
+		return "the top-level domain cannot be all numeric"
+	case RuleQuotedLocal:
+		if e.Char == '"' {
+			return "the quoted string must be closed with a double quote"
+		}
+		return "the quoted string cannot end with a backslash"
+	case RuleIPLiteral:
+		if e.Char == ']' {
+			return "the ip literal must be closed with a square bracket"
+		}
+		if len(e.Word) > 5 && strings.EqualFold(e.Word[:5], "IPv6:") {
+			return "the ip literal is not a valid ipv6 address: " + e.Word[5:]
+		}
+		return "the ip literal is not a valid ipv4 address: " + e.Word
+	case RulePunycode:
+		if e.Char == '-' {
+			return "the hyphens at the third and fourth positions are reserved for punycode"
+		}
+		return "the label is not valid punycode"
+	}
+
+	return "the string violates the rule: " + e.Rule.String()
+}
+
+// adjacencyMessage returns the message of the adjacency rules, what is the
+// rule on the neighbours of the char.
+func (e *ValidationError) adjacencyMessage(what string) string {
+	if e.Expected == "" {
+		return "the char: " + string(e.Char) + ", " + what + " the allowed characters"
+	}
+
+	return "the char: " + string(e.Char) + ", " + what + " these characters: " + e.Expected
+}
+
+// countLimit returns the limit of the count rules for the message, a count
+// under the limit violates the minimum.
+func (e *ValidationError) countLimit() string {
+	if e.Count < e.Limit {
+		return "at least " + strconv.Itoa(e.Limit) + " time(s)"
+	}
+
+	return "at most " + strconv.Itoa(e.Limit) + " time(s)"
+}
+
+// unitSuffix returns the unit of the length rules for the message. Bytes are
+// left out to keep the messages the same as before the units were added.
+func (e *ValidationError) unitSuffix() string {
+	if e.Unit == LengthDefault || e.Unit == LengthBytes {
+		return ""
+	}
+
+	return " " + e.Unit.String()
+}
+
+// Unwrap returns the sentinel error of the violated rule.
+func (e *ValidationError) Unwrap() error {
+	return e.Rule.Err()
+}
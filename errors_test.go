@@ -0,0 +1,132 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidationError_Byte(t *testing.T) {
+	err := strgo.Byte("johndoe", &strgo.ByteCondition{
+		OnlyContains: strgo.CharSetOf('j', 'o', 'h', 'n', 'd'),
+	})
+	assert.True(t, errors.Is(err, strgo.ErrOnlyContains))
+	assert.False(t, errors.Is(err, strgo.ErrMustNotContains))
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleOnlyContains, verr.Rule)
+	assert.Equal(t, 'e', verr.Char)
+	assert.Equal(t, 6, verr.Offset)
+	assert.EqualError(t, err, "the string cannot contain char: e")
+}
+
+func TestValidationError_ByteLimit(t *testing.T) {
+	err := strgo.Byte("johndoe", &strgo.ByteCondition{
+		MaxLength: 6,
+	})
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleMaxLength, verr.Rule)
+	assert.Equal(t, 6, verr.Limit)
+	assert.Equal(t, 7, verr.Count)
+	assert.Equal(t, -1, verr.Offset)
+	err = strgo.Byte("joHndoe", &strgo.ByteCondition{
+		AtLeastHaveUpperLetterCount: 2,
+	})
+	assert.True(t, errors.As(err, &verr))
+	assert.True(t, errors.Is(err, strgo.ErrAtLeastHaveUpperLetterCount))
+	assert.Equal(t, 2, verr.Limit)
+	assert.Equal(t, 1, verr.Count)
+}
+
+func TestValidationError_ByteOnce(t *testing.T) {
+	err := strgo.Byte("johndoe", &strgo.ByteCondition{
+		MustContainsOnce: strgo.CharSetOf('n', 'o'),
+	})
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleMustContainsOnce, verr.Rule)
+	assert.Equal(t, 5, verr.Offset)
+	assert.EqualError(t, err, "the char: o, must be appeared once in the string")
+	err = strgo.Byte("johndoe", &strgo.ByteCondition{
+		MustContainsOnce: strgo.CharSetOf('k'),
+	})
+	assert.True(t, errors.Is(err, strgo.ErrMustContainsOnce))
+	assert.EqualError(t, err, "the string must contain char: k")
+	err = strgo.Byte("johndoe", &strgo.ByteCondition{
+		MayContainsOnce: strgo.CharSetOf('o'),
+	})
+	assert.True(t, errors.Is(err, strgo.ErrMayContainsOnce))
+}
+
+func TestValidationError_String(t *testing.T) {
+	err := strgo.String("johndoedoe", &strgo.StringCondition{
+		MayContainsWordOnce: []string{"khn", "doe"},
+	})
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.True(t, errors.Is(err, strgo.ErrMayContainsWordOnce))
+	assert.Equal(t, "doe", verr.Word)
+	assert.Equal(t, 7, verr.Offset)
+	assert.Equal(t, 2, verr.Count)
+	err = strgo.String("johndoe", &strgo.StringCondition{
+		MustNotContainsWord: []string{"hng", "ohn"},
+	})
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleMustNotContainsWord, verr.Rule)
+	assert.Equal(t, 1, verr.Offset)
+	err = strgo.String("johndoe", &strgo.StringCondition{
+		MustNotContainsSuffixWord: []string{"doe"},
+	})
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, 4, verr.Offset)
+}
+
+func TestRule_String(t *testing.T) {
+	assert.Equal(t, "OnlyContains", strgo.RuleOnlyContains.String())
+	assert.Equal(t, "MustContainsWordOnce", strgo.RuleMustContainsWordOnce.String())
+	assert.Equal(t, "Rule(0)", strgo.Rule(0).String())
+}
+
+func TestByteValidator_ValidateAll(t *testing.T) {
+	v, err := strgo.CompileByte(&strgo.ByteCondition{
+		MinLength:                   8,
+		OnlyContains:                strgo.CharsSet,
+		MustNotContainsPrefix:       strgo.CharSetOf('.'),
+		AtLeastHaveUpperLetterCount: 1,
+		AtLeastHaveNumberCount:      1,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.ValidateAll("John.doe123"))
+	err = v.ValidateAll(".johndoe")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 3)
+	assert.Equal(t, strgo.RuleMustNotContainsPrefix, errs[0].Rule)
+	assert.Equal(t, strgo.RuleAtLeastHaveUpperLetterCount, errs[1].Rule)
+	assert.Equal(t, strgo.RuleAtLeastHaveNumberCount, errs[2].Rule)
+	assert.True(t, errors.Is(err, strgo.ErrAtLeastHaveNumberCount))
+	assert.EqualError(t, err, "the string must not contain prefix: .\nthe string must have at least 1 upper case letter(s)\nthe string must have at least 1 number(s)")
+	err = v.ValidateAll("jo\thn\ndoé")
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, []int{2, 5, 8, -1, -1}, []int{errs[0].Offset, errs[1].Offset, errs[2].Offset, errs[3].Offset, errs[4].Offset})
+	assert.Equal(t, strgo.RuleNotASCII, errs[2].Rule)
+	assert.EqualError(t, v.Validate(".johndoe"), "the string must not contain prefix: .")
+}
+
+func TestStringValidator_ValidateAll(t *testing.T) {
+	v, err := strgo.CompileString(&strgo.StringCondition{
+		MustContainsWord:    []string{"john"},
+		MustNotContainsWord: []string{"bad", "evil"},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.ValidateAll("johndoe"))
+	err = v.ValidateAll("evil bad doe")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "evil", errs[0].Word)
+	assert.Equal(t, "bad", errs[1].Word)
+	assert.Equal(t, "john", errs[2].Word)
+}
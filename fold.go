@@ -0,0 +1,163 @@
+package strgo
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FoldMode is how a case-insensitive StringCondition folds the text and the
+// words before matching them.
+type FoldMode int
+
+const (
+	// FoldASCII folds the ASCII letters only, so "BadWord" matches "badword"
+	// but "É" and "é" stay different.
+	FoldASCII FoldMode = iota
+	// FoldUnicode applies the full Unicode case folding, so "ÉTÉ" matches "été"
+	// and "STRASSE" matches "straße".
+	FoldUnicode
+	// FoldNFKC normalizes to NFKC before the Unicode case folding, so the
+	// compatibility forms like "ｂａｄ" or "ﬁ" match their plain letters.
+	FoldNFKC
+)
+
+// caseFolder is stateless, it's safe to share between goroutines.
+var caseFolder = cases.Fold()
+
+func lowerASCII(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+
+	return c
+}
+
+// folder folds and normalizes a text on the fly, the way a StringCondition
+// matches its words. Its buffers are kept between the calls, so it doesn't
+// allocate.
+type folder struct {
+	caseInsensitive bool
+	mode            FoldMode
+	normalizer      *Normalizer
+	it              norm.Iter
+	src             [utf8.UTFMax]byte
+	dst             [4 * utf8.UTFMax]byte
+	out             []byte
+	nfd             []byte
+}
+
+// string returns the folded and normalized form of s, the form the words are
+// matched in.
+func (f *folder) string(s string) string {
+	var b []byte
+	f.fold(s, func(c byte, _, _ int) {
+		b = append(b, c)
+	})
+
+	return string(b)
+}
+
+// fold folds and normalizes the text, and passes every resulting byte to emit
+// with the offset in the text of the char it comes from. The last byte that
+// comes from a char also gets the offset where the char ends, the other bytes
+// get -1.
+func (f *folder) fold(text string, emit func(b byte, at, end int)) {
+	nfkc := f.caseInsensitive && f.mode == FoldNFKC
+	switch {
+	case nfkc || f.normalizer != nil && f.normalizer.skeleton:
+		// The normalized segments don't keep the offsets of their runes, so
+		// every byte of a segment comes from the segment start. A char can be
+		// split in many segments, only the last one moves the position. A
+		// skeleton is made of the NFD segments.
+		form := norm.NFD
+		if nfkc {
+			form = norm.NFKC
+		}
+		f.it.InitString(form, text)
+		for !f.it.Done() {
+			at := f.it.Pos()
+			segment := f.it.Next()
+			end := f.it.Pos()
+			if end == at {
+				end = -1
+			}
+			foldRunes(f, segment, at, true, end, emit)
+		}
+	case f.caseInsensitive && f.mode == FoldASCII && f.normalizer == nil:
+		for i := 0; i < len(text); i++ {
+			emit(lowerASCII(text[i]), i, i+1)
+		}
+	default:
+		foldRunes(f, text, 0, false, -1, emit)
+	}
+}
+
+// foldRunes folds and normalizes the runes of text one by one, the offsets
+// passed to emit start at base. If piece is true, the text comes from the
+// single char at base, and end is passed with its last byte. The ASCII chars
+// are folded without a table lookup.
+func foldRunes[T string | []byte](f *folder, text T, base int, piece bool, end int, emit func(b byte, at, end int)) {
+	for i := 0; i < len(text); {
+		var out []byte
+		size := 1
+		if c := text[i]; c < utf8.RuneSelf {
+			f.dst[0] = c
+			if f.caseInsensitive {
+				f.dst[0] = lowerASCII(c)
+			}
+			out = f.dst[:1]
+		} else {
+			n := copy(f.src[:], text[i:])
+			_, size = utf8.DecodeRune(f.src[:n])
+			out = f.src[:size]
+			if f.caseInsensitive && f.mode != FoldASCII {
+				if folded, _, err := caseFolder.Transform(f.dst[:], f.src[:size], true); err == nil {
+					out = f.dst[:folded]
+				}
+			}
+		}
+		if f.normalizer != nil {
+			out = f.normalize(out)
+		}
+
+		at, last := base+i, base+i+size
+		if piece {
+			at, last = base, -1
+			if i+size == len(text) {
+				last = end
+			}
+		}
+		for j, b := range out {
+			if j < len(out)-1 {
+				emit(b, at, -1)
+			} else {
+				emit(b, at, last)
+			}
+		}
+		i += size
+	}
+}
+
+// normalize replaces the chars of b mapped by the normalizer, and decomposes
+// the result to NFD if the normalizer maps to the skeleton. The returned slice
+// is only valid until the next call.
+func (f *folder) normalize(b []byte) []byte {
+	f.out = f.out[:0]
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if s, ok := f.normalizer.lookup(r); ok && (r != utf8.RuneError || size > 1) {
+			f.out = append(f.out, s...)
+		} else {
+			f.out = append(f.out, b[i:i+size]...)
+		}
+		i += size
+	}
+	if f.normalizer.skeleton {
+		f.nfd = norm.NFD.Append(f.nfd[:0], f.out...)
+		return f.nfd
+	}
+
+	return f.out
+}
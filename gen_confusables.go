@@ -0,0 +1,106 @@
+//go:build ignore
+
+// gen_confusables generates normalize_confusables.txt, the table of the
+// Unicode confusables the LookalikeNormalizer maps, from the confusables.txt
+// of UTS #39: https://www.unicode.org/reports/tr39/
+//
+//	go run gen_confusables.go
+//	go run gen_confusables.go -file confusables.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	url  = flag.String("url", "https://www.unicode.org/Public/security/latest/confusables.txt", "URL of confusables.txt")
+	file = flag.String("file", "", "read confusables.txt from this file instead of the URL")
+	out  = flag.String("out", "normalize_confusables.txt", "the generated table")
+)
+
+func main() {
+	flag.Parse()
+
+	src, err := open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer src.Close()
+
+	var header, table bytes.Buffer
+	header.WriteString("# The confusables of UTS #39, generated by gen_confusables.go, DO NOT EDIT.\n")
+	header.WriteString("# Each char is mapped to its prototype, the char or the chars it looks like.\n#\n")
+	inHeader := true
+	lines := 0
+	scanner := bufio.NewScanner(src)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\uFEFF")
+		if inHeader && strings.HasPrefix(line, "#") {
+			header.WriteString(line + "\n")
+			continue
+		}
+		inHeader = false
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// A line is: source ; target ; type, the source is a code point and
+		// the target a list of them.
+		fields := strings.Split(line, ";")
+		if len(fields) < 2 {
+			log.Fatalf("invalid line: %q", line)
+		}
+		source := strings.TrimSpace(fields[0])
+		if _, err := strconv.ParseUint(source, 16, 32); err != nil {
+			log.Fatalf("invalid source: %q", line)
+		}
+		fmt.Fprintf(&table, "U+%s", source)
+		for _, code := range strings.Fields(fields[1]) {
+			if _, err := strconv.ParseUint(code, 16, 32); err != nil {
+				log.Fatalf("invalid target: %q", line)
+			}
+			fmt.Fprintf(&table, "\tU+%s", code)
+		}
+		table.WriteString("\n")
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	if lines == 0 {
+		log.Fatal("no confusables found")
+	}
+
+	header.WriteString("\n")
+	if err := os.WriteFile(*out, append(header.Bytes(), table.Bytes()...), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func open() (io.ReadCloser, error) {
+	if *file != "" {
+		return os.Open(*file)
+	}
+	resp, err := http.Get(*url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", *url, resp.Status)
+	}
+
+	return resp.Body, nil
+}
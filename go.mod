@@ -2,7 +2,11 @@ module github.com/dalikewara/strgo
 
 go 1.18
 
-require github.com/stretchr/testify v1.8.0
+require (
+	github.com/rivo/uniseg v0.4.7
+	github.com/stretchr/testify v1.8.0
+	golang.org/x/text v0.21.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,226 @@
+package strgo
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// HostnameOptions are the options of Hostname. The zero value validates an
+// RFC 1123 hostname, where a single label like "localhost" is allowed.
+type HostnameOptions struct {
+	// RequireTLD requires at least two labels, and a top-level domain that
+	// isn't all numeric.
+	RequireTLD bool
+	// AllowTrailingDot allows the root dot at the end, like "email.com.".
+	AllowTrailingDot bool
+	// AllowUnderscore allows underscores in the labels, like the SRV record
+	// "_sip._tcp.email.com".
+	AllowUnderscore bool
+	// CheckPunycode decodes the "xn--" labels to check that they're valid
+	// punycode, and rejects other labels with hyphens at the third and fourth
+	// positions, which are reserved.
+	CheckPunycode bool
+}
+
+// LabelError is returned by Hostname when a label is invalid. It unwraps to
+// the error of the label, which is a *ValidationError for the label rules, with
+// an offset in the label.
+type LabelError struct {
+	Index int
+	Label string
+	Err   error
+}
+
+func (e *LabelError) Error() string {
+	return "the label at index " + strconv.Itoa(e.Index) + " (" + strconv.Quote(e.Label) + ") is invalid: " + e.Err.Error()
+}
+
+func (e *LabelError) Unwrap() error {
+	return e.Err
+}
+
+const (
+	hostnameMaxLength = 253
+	labelMaxLength    = 63
+)
+
+var labelValidator = MustCompileByte(&ByteCondition{
+	MaxLength:             labelMaxLength,
+	OnlyContains:          AlphanumericSet.Union(CharSetOf('-')),
+	MustNotContainsPrefix: CharSetOf('-'),
+	MustNotContainsSuffix: CharSetOf('-'),
+})
+
+var labelUnderscoreValidator = MustCompileByte(&ByteCondition{
+	MaxLength:             labelMaxLength,
+	OnlyContains:          AlphanumericSet.Union(CharSetOf('-', '_')),
+	MustNotContainsPrefix: CharSetOf('-'),
+	MustNotContainsSuffix: CharSetOf('-'),
+})
+
+// Hostname validates a hostname or a domain name: dot-separated labels of 1 to
+// 63 alphanumeric chars or hyphens, that don't start or end with a hyphen, and
+// 253 chars in total.
+// If a label is invalid, it will return a *LabelError.
+func Hostname(text string, opts HostnameOptions) error {
+	if opts.AllowTrailingDot && len(text) > 1 && text[len(text)-1] == '.' {
+		text = text[:len(text)-1]
+	}
+	if text == "" {
+		return &ValidationError{Rule: RuleEmpty, Offset: -1}
+	}
+	if len(text) > hostnameMaxLength {
+		return &ValidationError{Rule: RuleMaxLength, Offset: -1, Limit: hostnameMaxLength, Count: len(text), Unit: LengthBytes}
+	}
+
+	validator := labelValidator
+	if opts.AllowUnderscore {
+		validator = labelUnderscoreValidator
+	}
+
+	labels := strings.Split(text, ".")
+	for i, label := range labels {
+		if label == "" {
+			return &LabelError{Index: i, Label: label, Err: &ValidationError{Rule: RuleEmpty, Offset: -1}}
+		}
+		if err := validator.Validate(label); err != nil {
+			return &LabelError{Index: i, Label: label, Err: err}
+		}
+		if opts.CheckPunycode {
+			if err := checkPunycodeLabel(label); err != nil {
+				return &LabelError{Index: i, Label: label, Err: err}
+			}
+		}
+	}
+	if !opts.RequireTLD {
+		return nil
+	}
+	if len(labels) < 2 {
+		return &ValidationError{Rule: RuleRequireTLD, Offset: -1}
+	}
+	if tld := labels[len(labels)-1]; strings.Trim(tld, "0123456789") == "" {
+		return &ValidationError{Rule: RuleRequireTLD, Word: tld, Offset: len(text) - len(tld)}
+	}
+
+	return nil
+}
+
+// checkPunycodeLabel checks that an "xn--" label is valid punycode, and that no
+// other label uses the reserved "--" at the third and fourth positions.
+func checkPunycodeLabel(label string) error {
+	if len(label) < 4 || label[2:4] != "--" {
+		return nil
+	}
+	if !strings.EqualFold(label[:2], "xn") {
+		return &ValidationError{Rule: RulePunycode, Char: '-', Offset: 2}
+	}
+	if _, ok := decodePunycode(strings.ToLower(label[4:])); !ok {
+		return &ValidationError{Rule: RulePunycode, Word: label, Offset: 0}
+	}
+
+	return nil
+}
+
+// Punycode parameters, see RFC 3492 section 5.
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+// decodePunycode decodes a punycode string, the part of an "xn--" label after
+// the prefix, as described by RFC 3492 section 6.2. It returns false if s isn't
+// valid punycode.
+func decodePunycode(s string) (string, bool) {
+	var output []rune
+	if d := strings.LastIndexByte(s, '-'); d >= 0 {
+		for i := 0; i < d; i++ {
+			output = append(output, rune(s[i]))
+		}
+		s = s[d+1:]
+	}
+	if s == "" {
+		return "", false
+	}
+
+	n, bias, i := punycodeInitialN, punycodeInitialBias, 0
+	for pos := 0; pos < len(s); {
+		oldi, w := i, 1
+		for k := punycodeBase; ; k += punycodeBase {
+			if pos == len(s) {
+				return "", false
+			}
+			digit := punycodeDigit(s[pos])
+			pos++
+			if digit < 0 || digit > (math.MaxInt32-i)/w {
+				return "", false
+			}
+			i += digit * w
+			t := k - bias
+			if t < punycodeTMin {
+				t = punycodeTMin
+			} else if t > punycodeTMax {
+				t = punycodeTMax
+			}
+			if digit < t {
+				break
+			}
+			if w > math.MaxInt32/(punycodeBase-t) {
+				return "", false
+			}
+			w *= punycodeBase - t
+		}
+		size := len(output) + 1
+		bias = punycodeAdapt(i-oldi, size, oldi == 0)
+		if i/size > math.MaxInt32-n {
+			return "", false
+		}
+		n += i / size
+		i %= size
+		if n > utf8.MaxRune || !utf8.ValidRune(rune(n)) {
+			return "", false
+		}
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+
+	return string(output), true
+}
+
+func punycodeDigit(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a')
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A')
+	}
+
+	return -1
+}
+
+func punycodeAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
@@ -0,0 +1,91 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestHostname_Options(t *testing.T) {
+	tests := []struct {
+		text  string
+		opts  strgo.HostnameOptions
+		valid bool
+	}{
+		{"localhost", strgo.HostnameOptions{}, true},
+		{"localhost", strgo.HostnameOptions{RequireTLD: true}, false},
+		{"tenant-1.email.com", strgo.HostnameOptions{RequireTLD: true}, true},
+		{"127.0.0.1", strgo.HostnameOptions{}, true},
+		{"127.0.0.1", strgo.HostnameOptions{RequireTLD: true}, false},
+		{"-tenant.email.com", strgo.HostnameOptions{}, false},
+		{"tenant-.email.com", strgo.HostnameOptions{}, false},
+		{"tenant..email.com", strgo.HostnameOptions{}, false},
+		{".email.com", strgo.HostnameOptions{}, false},
+		{"email.com.", strgo.HostnameOptions{}, false},
+		{"email.com.", strgo.HostnameOptions{AllowTrailingDot: true}, true},
+		{".", strgo.HostnameOptions{AllowTrailingDot: true}, false},
+		{"email.com..", strgo.HostnameOptions{AllowTrailingDot: true}, false},
+		{"_sip._tcp.email.com", strgo.HostnameOptions{}, false},
+		{"_sip._tcp.email.com", strgo.HostnameOptions{AllowUnderscore: true}, true},
+		{strings.Repeat("a", 63) + ".com", strgo.HostnameOptions{}, true},
+		{strings.Repeat("a", 64) + ".com", strgo.HostnameOptions{}, false},
+		{strings.Repeat("abcdefghi.", 25) + "com", strgo.HostnameOptions{}, true},
+		{strings.Repeat("abcdefghi.", 25) + "abcd", strgo.HostnameOptions{}, false},
+		{"tenant email.com", strgo.HostnameOptions{}, false},
+		{"", strgo.HostnameOptions{}, false},
+		{"xn--bcher-kva.com", strgo.HostnameOptions{CheckPunycode: true}, true},
+		{"XN--BCHER-KVA.com", strgo.HostnameOptions{CheckPunycode: true}, true},
+		{"xn--mnchen-3ya.de", strgo.HostnameOptions{CheckPunycode: true}, true},
+		{"xn--fiqs8s.cn", strgo.HostnameOptions{CheckPunycode: true}, true},
+		{"xn--bcher-kva9.com", strgo.HostnameOptions{CheckPunycode: true}, false},
+		{"xn--99999999999.com", strgo.HostnameOptions{CheckPunycode: true}, false},
+		{"xn--abc-.com", strgo.HostnameOptions{}, false},
+		{"xn--abc.com", strgo.HostnameOptions{}, true},
+		{"ab--cd.com", strgo.HostnameOptions{CheckPunycode: true}, false},
+		{"ab--cd.com", strgo.HostnameOptions{}, true},
+		{"abc--d.com", strgo.HostnameOptions{CheckPunycode: true}, true},
+	}
+	for _, tt := range tests {
+		err := strgo.Hostname(tt.text, tt.opts)
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+}
+
+func TestHostname_Error(t *testing.T) {
+	err := strgo.Hostname("tenant.email_1.com", strgo.HostnameOptions{})
+	var lerr *strgo.LabelError
+	assert.True(t, errors.As(err, &lerr))
+	assert.Equal(t, 1, lerr.Index)
+	assert.Equal(t, "email_1", lerr.Label)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleOnlyContains, verr.Rule)
+	assert.Equal(t, 5, verr.Offset)
+	assert.EqualError(t, err, `the label at index 1 ("email_1") is invalid: the string cannot contain char: _`)
+	err = strgo.Hostname("tenant..com", strgo.HostnameOptions{})
+	assert.True(t, errors.Is(err, strgo.ErrEmpty))
+	err = strgo.Hostname(strings.Repeat("a.", 127)+"a", strgo.HostnameOptions{})
+	assert.True(t, errors.Is(err, strgo.ErrMaxLength))
+	err = strgo.Hostname("localhost", strgo.HostnameOptions{RequireTLD: true})
+	assert.True(t, errors.Is(err, strgo.ErrRequireTLD))
+	assert.EqualError(t, err, "the hostname must have a top-level domain")
+	err = strgo.Hostname("10.0.0.1", strgo.HostnameOptions{RequireTLD: true})
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleRequireTLD, verr.Rule)
+	assert.Equal(t, "1", verr.Word)
+	assert.Equal(t, 7, verr.Offset)
+	assert.EqualError(t, err, "the top-level domain cannot be all numeric")
+	err = strgo.Hostname("ab--cd.com", strgo.HostnameOptions{CheckPunycode: true})
+	assert.True(t, errors.Is(err, strgo.ErrPunycode))
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, 2, verr.Offset)
+	assert.EqualError(t, err, `the label at index 0 ("ab--cd") is invalid: the hyphens at the third and fourth positions are reserved for punycode`)
+	err = strgo.Hostname("www.xn--bcher-kva9.com", strgo.HostnameOptions{CheckPunycode: true})
+	assert.True(t, errors.Is(err, strgo.ErrPunycode))
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RulePunycode, verr.Rule)
+	assert.Equal(t, "xn--bcher-kva9", verr.Word)
+	assert.EqualError(t, err, `the label at index 1 ("xn--bcher-kva9") is invalid: the label is not valid punycode`)
+}
@@ -0,0 +1,107 @@
+package strgo
+
+import (
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// LengthUnit is the unit MinLength and MaxLength are measured in.
+type LengthUnit int
+
+const (
+	// LengthDefault is the natural unit of the condition: bytes for ByteCondition
+	// and StringCondition, runes for RuneCondition.
+	LengthDefault LengthUnit = iota
+	// LengthBytes counts bytes, like len.
+	LengthBytes
+	// LengthRunes counts Unicode code points.
+	LengthRunes
+	// LengthGraphemes counts user-perceived characters (extended grapheme
+	// clusters), so "é" written as "e" + U+0301 or a flag emoji count as one.
+	LengthGraphemes
+	// LengthDisplayWidth counts monospace terminal columns, East Asian wide and
+	// fullwidth characters count as two and combining marks as zero.
+	LengthDisplayWidth
+)
+
+// String returns the name of the unit used in error messages.
+func (u LengthUnit) String() string {
+	switch u {
+	case LengthRunes:
+		return "runes"
+	case LengthGraphemes:
+		return "graphemes"
+	case LengthDisplayWidth:
+		return "columns"
+	}
+
+	return "bytes"
+}
+
+// orDefault returns the unit, or def if the unit is LengthDefault.
+func (u LengthUnit) orDefault(def LengthUnit) LengthUnit {
+	if u == LengthDefault {
+		return def
+	}
+
+	return u
+}
+
+// measureLength returns the length of the text in the given unit.
+func measureLength[T string | []byte](text T, unit LengthUnit) int {
+	switch unit {
+	case LengthDefault, LengthBytes:
+		return len(text)
+	}
+
+	switch t := any(text).(type) {
+	case string:
+		switch unit {
+		case LengthRunes:
+			return utf8.RuneCountInString(t)
+		case LengthGraphemes:
+			return uniseg.GraphemeClusterCount(t)
+		case LengthDisplayWidth:
+			return uniseg.StringWidth(t)
+		}
+	case []byte:
+		if unit == LengthRunes {
+			return utf8.RuneCount(t)
+		}
+		n, width, state := 0, 0, -1
+		for len(t) > 0 {
+			var w int
+			_, t, w, state = uniseg.FirstGraphemeCluster(t, state)
+			n++
+			width += w
+		}
+		if unit == LengthDisplayWidth {
+			return width
+		}
+		return n
+	}
+
+	return len(text)
+}
+
+// checkLength reports the MinLength and MaxLength violations of the text,
+// it returns true if the validation must stop.
+func checkLength[T string | []byte](text T, minLength, maxLength int, unit LengthUnit, errs *collector) bool {
+	if minLength < 1 && maxLength < 1 {
+		return false
+	}
+
+	return checkLengthOf(measureLength(text, unit), minLength, maxLength, unit, errs)
+}
+
+func checkLengthOf(n, minLength, maxLength int, unit LengthUnit, errs *collector) bool {
+	if minLength > 0 && n < minLength && errs.add(&ValidationError{Rule: RuleMinLength, Offset: -1, Limit: minLength, Count: n, Unit: unit}) {
+		return true
+	}
+	if maxLength > 0 && n > maxLength && errs.add(&ValidationError{Rule: RuleMaxLength, Offset: -1, Limit: maxLength, Count: n, Unit: unit}) {
+		return true
+	}
+
+	return false
+}
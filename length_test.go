@@ -0,0 +1,59 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLengthUnit_Runes(t *testing.T) {
+	cond := &strgo.StringCondition{
+		MaxLength:  4,
+		LengthUnit: strgo.LengthRunes,
+	}
+	assert.Nil(t, strgo.String("José", cond))
+	err := strgo.String("Josés", cond)
+	assert.EqualError(t, err, "the string length cannot be more than 4 runes")
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.LengthRunes, verr.Unit)
+	assert.Equal(t, 5, verr.Count)
+}
+
+func TestLengthUnit_Graphemes(t *testing.T) {
+	cond := &strgo.StringCondition{
+		MinLength:  4,
+		MaxLength:  4,
+		LengthUnit: strgo.LengthGraphemes,
+	}
+	assert.Nil(t, strgo.String("José", cond))
+	assert.Nil(t, strgo.String("hi🇮🇩👍🏽", cond))
+	assert.EqualError(t, strgo.String("Jos", cond), "the string length cannot be less than 4 graphemes")
+	assert.Nil(t, strgo.Byte("ab\r\nc", &strgo.ByteCondition{
+		MaxLength:  4,
+		LengthUnit: strgo.LengthGraphemes,
+	}))
+}
+
+func TestLengthUnit_DisplayWidth(t *testing.T) {
+	cond := &strgo.RuneCondition{
+		MaxLength:  4,
+		LengthUnit: strgo.LengthDisplayWidth,
+	}
+	assert.Nil(t, strgo.Rune("你好", cond))
+	assert.Nil(t, strgo.Rune("abcd", cond))
+	assert.EqualError(t, strgo.Rune("你好a", cond), "the string length cannot be more than 4 columns")
+}
+
+func TestLengthUnit_Bytes(t *testing.T) {
+	err := strgo.Rune("José", &strgo.RuneCondition{
+		MaxLength:  4,
+		LengthUnit: strgo.LengthBytes,
+	})
+	assert.EqualError(t, err, "the string length cannot be more than 4")
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.LengthBytes, verr.Unit)
+	assert.Equal(t, 5, verr.Count)
+}
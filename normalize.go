@@ -0,0 +1,224 @@
+package strgo
+
+import (
+	_ "embed"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer maps chars to a canonical form before the words of a
+// StringCondition are matched, so the leetspeak and look-alike spellings of a
+// listed word match it too: with the LookalikeNormalizer, "4dm1n", "adm!n" and
+// "аdmin" (with a Cyrillic а) all match "admin". The words are normalized the
+// same way as the text.
+// A Normalizer is immutable and safe for concurrent use by multiple goroutines.
+type Normalizer struct {
+	ascii  [utf8.RuneSelf]string
+	mapped CharSet
+	runes  map[rune]string
+	// skeleton tells if the text is decomposed to NFD before and after the
+	// chars are mapped, the skeleton algorithm of UTS #39.
+	skeleton bool
+	// fill builds the mapping on the first use, so a big table is only parsed
+	// by the programs that use it.
+	fill   func(n *Normalizer)
+	filled sync.Once
+}
+
+//go:generate go run gen_confusables.go
+
+//go:embed normalize.txt
+var lookalikeNormalizerTable string
+
+//go:embed normalize_confusables.txt
+var confusablesTable string
+
+// LookalikeNormalizer maps the text to its skeleton, as UTS #39 defines it to
+// detect the confusable strings: the text is decomposed to NFD, each char is
+// mapped to the char it looks like, the prototype, and the result is
+// decomposed to NFD again. The prototypes come from the confusables.txt of
+// UTS #39, generated into normalize_confusables.txt, so the Cyrillic, Greek and
+// other letters that look like Latin ones match them. The leetspeak
+// substitutions and the invisible chars of normalize.txt override it.
+//
+// Use With to override some of its mapping, the copy keeps the skeleton steps,
+// or ParseNormalizer to replace it with a plain mapping.
+// Ref: https://www.unicode.org/reports/tr39/#Confusable_Detection
+var LookalikeNormalizer = &Normalizer{skeleton: true, fill: fillLookalike}
+
+// fillLookalike builds the mapping of the LookalikeNormalizer, its confusables
+// table has thousands of chars.
+func fillLookalike(n *Normalizer) {
+	confusables := MustParseNormalizer(confusablesTable)
+	n.ascii, n.mapped, n.runes = confusables.ascii, confusables.mapped, confusables.runes
+	leet := MustParseNormalizer(lookalikeNormalizerTable)
+	for r, s := range leet.ascii {
+		if leet.mapped.Contains(byte(r)) {
+			n.set(rune(r), s)
+		}
+	}
+	for r, s := range leet.runes {
+		n.set(r, s)
+	}
+}
+
+// ready builds the mapping of n if it isn't yet, it must be called before n is
+// used.
+func (n *Normalizer) ready() *Normalizer {
+	if n.fill != nil {
+		n.filled.Do(func() { n.fill(n) })
+	}
+
+	return n
+}
+
+// ParseNormalizer parses a mapping table: one char per line, followed by
+// spaces and what it's normalized to. A char is written as itself or as U+XXXX,
+// and a char without a replacement is removed. A replacement of many chars is
+// written as they are, or as U+XXXX codes separated by spaces. The lines
+// starting with # are comments.
+func ParseNormalizer(table string) (*Normalizer, error) {
+	n := &Normalizer{runes: map[rune]string{}}
+
+	for i, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) > 2 && !allCodes(fields[1:]) {
+			return nil, errors.New("the normalizer table line " + strconv.Itoa(i+1) + " is invalid: it must have a char and its replacement")
+		}
+		from, err := parseNormalizerChars(fields[0])
+		if err == nil && utf8.RuneCountInString(from) != 1 {
+			err = errors.New("it must start with a single char")
+		}
+		to := ""
+		for _, field := range fields[1:] {
+			if err != nil {
+				break
+			}
+			var chars string
+			chars, err = parseNormalizerChars(field)
+			to += chars
+		}
+		if err != nil {
+			return nil, errors.New("the normalizer table line " + strconv.Itoa(i+1) + " is invalid: " + err.Error())
+		}
+		r, _ := utf8.DecodeRuneInString(from)
+		n.set(r, to)
+	}
+
+	return n, nil
+}
+
+// MustParseNormalizer is like ParseNormalizer but panics if the table can't be
+// parsed. It's meant for package-level variables.
+func MustParseNormalizer(table string) *Normalizer {
+	n, err := ParseNormalizer(table)
+	if err != nil {
+		panic("strgo: MustParseNormalizer: " + err.Error())
+	}
+
+	return n
+}
+
+// parseNormalizerChars parses a field of the table, the chars as themselves or
+// a U+XXXX code.
+func parseNormalizerChars(field string) (string, error) {
+	if !strings.HasPrefix(field, "U+") || len(field) == 2 {
+		if !utf8.ValidString(field) {
+			return "", errors.New("it has an invalid utf-8 char")
+		}
+		return field, nil
+	}
+
+	code, err := strconv.ParseUint(field[2:], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return "", errors.New("the code: " + field + ", is not a valid char")
+	}
+
+	return string(rune(code)), nil
+}
+
+// allCodes tells if the fields are all U+XXXX codes.
+func allCodes(fields []string) bool {
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "U+") || len(field) == 2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// With returns a copy of the Normalizer with the mapping added, replacing the
+// chars it already maps. A char mapped to itself is left as it is, and a char
+// mapped to "" is removed.
+func (n *Normalizer) With(mapping map[rune]string) *Normalizer {
+	n.ready()
+	c := &Normalizer{ascii: n.ascii, mapped: n.mapped, runes: make(map[rune]string, len(n.runes)+len(mapping)), skeleton: n.skeleton}
+	for r, s := range n.runes {
+		c.runes[r] = s
+	}
+	for r, s := range mapping {
+		c.set(r, s)
+	}
+
+	return c
+}
+
+func (n *Normalizer) set(r rune, to string) {
+	if r < utf8.RuneSelf {
+		n.ascii[r] = to
+		n.mapped.add(byte(r))
+		if to == string(r) {
+			n.mapped.remove(byte(r))
+		}
+		return
+	}
+	n.runes[r] = to
+	if to == string(r) {
+		delete(n.runes, r)
+	}
+}
+
+// Normalize returns the text with every mapped char replaced, decomposed to
+// NFD before and after if the Normalizer maps to the skeleton. The invalid
+// UTF-8 bytes are kept as they are.
+func (n *Normalizer) Normalize(text string) string {
+	n.ready()
+	if n.skeleton {
+		text = norm.NFD.String(text)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if s, ok := n.lookup(r); ok && (r != utf8.RuneError || size > 1) {
+			sb.WriteString(s)
+		} else {
+			sb.WriteString(text[i : i+size])
+		}
+		i += size
+	}
+	if n.skeleton {
+		return norm.NFD.String(sb.String())
+	}
+
+	return sb.String()
+}
+
+// lookup returns the replacement of r, false if r isn't mapped.
+func (n *Normalizer) lookup(r rune) (string, bool) {
+	if r < utf8.RuneSelf {
+		return n.ascii[r], n.mapped.Contains(byte(r))
+	}
+	s, ok := n.runes[r]
+
+	return s, ok
+}
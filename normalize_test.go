@@ -0,0 +1,105 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	n := strgo.LookalikeNormalizer
+	for _, text := range []string{"4dm1n", "adm!n", "аdmin", "ad\u200bmin", "ɑdmin", "𝐚dmin"} {
+		assert.Equal(t, n.Normalize("admin"), n.Normalize(text), text)
+	}
+	assert.Equal(t, "adrnin", n.Normalize("admin"))
+	assert.Equal(t, n.Normalize("ADMIN"), n.Normalize("АDMIN"))
+	assert.Equal(t, n.Normalize("ADMIN"), n.Normalize("ᎪDMIN"))
+	assert.NotEqual(t, n.Normalize("admin"), n.Normalize("ADMIN"))
+
+	// The skeleton is decomposed before and after the chars are mapped, so
+	// the precomposed Cyrillic ё matches the Latin ë, precomposed or not.
+	assert.Equal(t, n.Normalize("ë"), n.Normalize("ё"))
+	assert.Equal(t, n.Normalize("e\u0308"), n.Normalize("ё"))
+	assert.Equal(t, "e\u0308", n.Normalize("ë"))
+
+	custom := n.With(map[rune]string{'1': "l", '4': "4", '¢': "c"})
+	assert.Equal(t, "4drnln c", custom.Normalize("4dm1n ¢"))
+	assert.Equal(t, "coo", custom.Normalize("¢00"))
+	assert.Equal(t, n.Normalize("admin"), n.Normalize("4dm1n"))
+}
+
+func TestParseNormalizer(t *testing.T) {
+	n, err := strgo.ParseNormalizer("# comment\n\nv\tu\nU+0430 a\nw vv\nU+200B\nm U+0072\tU+006E\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "uavvxrn", n.Normalize("vаw\u200bxm"))
+	assert.Equal(t, "ë", n.Normalize("ë"))
+
+	for _, table := range []string{"ab c", "a b c", "a U+0062 c", "a U+0062 U+ZZZZ", "U+ZZZZ a", "U+D800 a", "a \xff"} {
+		_, err := strgo.ParseNormalizer(table)
+		assert.NotNil(t, err, table)
+	}
+	_, err = strgo.ParseNormalizer("a b\nab c")
+	assert.EqualError(t, err, "the normalizer table line 2 is invalid: it must start with a single char")
+	assert.Panics(t, func() { strgo.MustParseNormalizer("ab c") })
+}
+
+func TestString_Normalizer(t *testing.T) {
+	cond := &strgo.StringCondition{
+		MustNotContainsWord: []string{"admin"},
+		CaseInsensitive:     true,
+		FoldMode:            strgo.FoldUnicode,
+		Normalizer:          strgo.LookalikeNormalizer,
+	}
+	for _, text := range []string{"admin", "4dm1n", "adm!n", "АDMIN", "ad\u200bmin", "the @dm!n", "ɑdmin", "𝐚dm1n"} {
+		assert.NotNil(t, strgo.String(text, cond), text)
+	}
+	assert.Nil(t, strgo.String("administrator", &strgo.StringCondition{
+		MustNotContainsWord: []string{"admin"},
+		Normalizer:          strgo.LookalikeNormalizer,
+		WholeWord:           true,
+	}))
+
+	err := strgo.String("hi, аdm1n!", cond)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "admin", verr.Word)
+	assert.Equal(t, 4, verr.Offset)
+	assert.Equal(t, len("аdm1n"), verr.Length)
+	assert.Equal(t, "аdm1n", "hi, аdm1n!"[verr.Offset:verr.Offset+verr.Length])
+
+	err = strgo.String("ok ad\u200bmin", cond)
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "ad\u200bmin", "ok ad\u200bmin"[verr.Offset:verr.Offset+verr.Length])
+
+	err = strgo.String("née 𝐚dmin", cond)
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "𝐚dmin", "née 𝐚dmin"[verr.Offset:verr.Offset+verr.Length])
+
+	cond.Normalizer = nil
+	assert.Nil(t, strgo.String("4dm1n", cond))
+}
+
+func TestString_Normalizer_Length(t *testing.T) {
+	v, err := strgo.CompileString(&strgo.StringCondition{
+		MustNotContainsWord:       []string{"fi", "x"},
+		MustNotContainsPrefixWord: []string{"ss"},
+		CaseInsensitive:           true,
+		FoldMode:                  strgo.FoldNFKC,
+	})
+	assert.Nil(t, err)
+
+	err = v.ValidateAll("ßaﬁx")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 3)
+	for _, e := range errs {
+		assert.Equal(t, map[string]string{"ss": "ß", "fi": "ﬁ", "x": "x"}[e.Word], "ßaﬁx"[e.Offset:e.Offset+e.Length], e.Word)
+	}
+
+	err = strgo.String("a word", &strgo.StringCondition{MustNotContainsWord: []string{"word"}})
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, 2, verr.Offset)
+	assert.Equal(t, 4, verr.Length)
+}
@@ -0,0 +1,616 @@
+package strgo
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PatternError is a syntax error of a pattern, at the Line and the Column of
+// the token that caused it. Both start at 1, the column is counted in bytes.
+type PatternError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *PatternError) Error() string {
+	return "the pattern at line: " + strconv.Itoa(e.Line) + ", column: " + strconv.Itoa(e.Column) + ", " + e.Err.Error()
+}
+
+func (e *PatternError) Unwrap() error {
+	return e.Err
+}
+
+// ParsePattern parses a pattern into a ByteCondition. A pattern is a list of
+// statements separated by ";" or new lines, "#" starts a comment up to the end
+// of the line. A SET is written in brackets with the syntax of ParseCharSet, a
+// RANGE is written N, N.., ..M or N..M:
+//
+//	len RANGE                MinLength and MaxLength
+//	unit UNIT                LengthUnit: bytes, runes, graphemes or width
+//	only SET                 OnlyContains
+//	prefix SET               OnlyContainsPrefix
+//	suffix SET               OnlyContainsSuffix
+//	must SET                 MustContains
+//	must-once SET            MustContainsOnce
+//	not SET                  MustNotContains
+//	no-prefix SET            MustNotContainsPrefix
+//	no-suffix SET            MustNotContainsSuffix
+//	follow SET by SET        a MustBeFollowedBy pair, and the same for precede,
+//	                         surround, not-follow and not-precede
+//	adjacent SET DIRECTION [not] SET
+//	                         an Adjacency rule, the direction is next, previous
+//	                         or both
+//	once SET                 MayContainsOnce
+//	upper N, lower N, number N, special N
+//	                         the AtLeastHave*Count rules
+//	count-each SET RANGE     the CharCount of each char of the set
+//	count SET RANGE          a ClassCount
+//	repeat N                 MaxRepeatRun
+//	sequence N               MaxSequentialRun
+//	class-run N              MaxSameClassRun
+//	at INDEX SET             a PositionSets entry
+//	prefix-sets SET...       PrefixSets
+//	suffix-sets SET...       SuffixSets
+//
+// For example:
+//
+//	len 3..20; only [a-zA-Z0-9_.]; once [_.]; surround [_.] by [a-zA-Z0-9]; no-prefix [_.]
+//
+// A statement that sets a field replaces what an earlier one set, the pairs,
+// the rules and the counts are added to the ones before them. The errors are
+// returned as a *PatternError.
+func ParsePattern(pattern string) (*ByteCondition, error) {
+	statements, err := lexPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	cond := &ByteCondition{}
+	for _, tokens := range statements {
+		p := patternParser{tokens: tokens}
+		if err := p.parse(cond); err != nil {
+			return nil, err
+		}
+	}
+
+	return cond, nil
+}
+
+// CompilePattern parses a pattern and compiles its condition.
+func CompilePattern(pattern string) (*ByteValidator, error) {
+	cond, err := ParsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompileByte(cond)
+}
+
+// MustCompilePattern is like CompilePattern but panics if the pattern can't be
+// parsed or compiled. It's meant for package-level variables.
+func MustCompilePattern(pattern string) *ByteValidator {
+	v, err := CompilePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// patternSets are the statements of the CharSet fields, in the order of the
+// fields.
+var patternSets = [...]struct {
+	keyword string
+	field   func(cond *ByteCondition) *CharSet
+}{
+	{"only", func(cond *ByteCondition) *CharSet { return &cond.OnlyContains }},
+	{"prefix", func(cond *ByteCondition) *CharSet { return &cond.OnlyContainsPrefix }},
+	{"suffix", func(cond *ByteCondition) *CharSet { return &cond.OnlyContainsSuffix }},
+	{"must", func(cond *ByteCondition) *CharSet { return &cond.MustContains }},
+	{"must-once", func(cond *ByteCondition) *CharSet { return &cond.MustContainsOnce }},
+	{"not", func(cond *ByteCondition) *CharSet { return &cond.MustNotContains }},
+	{"no-prefix", func(cond *ByteCondition) *CharSet { return &cond.MustNotContainsPrefix }},
+	{"no-suffix", func(cond *ByteCondition) *CharSet { return &cond.MustNotContainsSuffix }},
+}
+
+// patternPairs are the statements of the adjacency rule pairs.
+var patternPairs = [...]struct {
+	keyword string
+	field   func(cond *ByteCondition) *[][2]CharSet
+}{
+	{"follow", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustBeFollowedBy }},
+	{"precede", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustBePrecededBy }},
+	{"surround", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustBeSurroundedBy }},
+	{"not-follow", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustNotBeFollowedBy }},
+	{"not-precede", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustNotBePrecededBy }},
+}
+
+// patternInts are the statements of the int fields, in the order of the fields.
+var patternInts = [...]struct {
+	keyword string
+	field   func(cond *ByteCondition) *int
+}{
+	{"upper", func(cond *ByteCondition) *int { return &cond.AtLeastHaveUpperLetterCount }},
+	{"lower", func(cond *ByteCondition) *int { return &cond.AtLeastHaveLowerLetterCount }},
+	{"number", func(cond *ByteCondition) *int { return &cond.AtLeastHaveNumberCount }},
+	{"special", func(cond *ByteCondition) *int { return &cond.AtLeastHaveSpecialCharCount }},
+	{"repeat", func(cond *ByteCondition) *int { return &cond.MaxRepeatRun }},
+	{"sequence", func(cond *ByteCondition) *int { return &cond.MaxSequentialRun }},
+	{"class-run", func(cond *ByteCondition) *int { return &cond.MaxSameClassRun }},
+}
+
+var patternDirections = [...]string{
+	DirectionNext:     "next",
+	DirectionPrevious: "previous",
+	DirectionBoth:     "both",
+}
+
+// patternToken is a word or a set of a pattern, with the position of its
+// first byte.
+type patternToken struct {
+	text   string
+	line   int
+	column int
+}
+
+func (t patternToken) errorf(msg string) error {
+	return &PatternError{Line: t.line, Column: t.column, Err: errors.New(msg)}
+}
+
+// lexPattern splits a pattern into its statements, the empty ones are skipped.
+func lexPattern(pattern string) ([][]patternToken, error) {
+	var statements [][]patternToken
+	var tokens []patternToken
+	line, lineStart := 1, 0
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == ';' || c == '\n' || c == '#':
+			if c == '#' {
+				for i < len(pattern) && pattern[i] != '\n' {
+					i++
+				}
+				continue
+			}
+			if len(tokens) > 0 {
+				statements = append(statements, tokens)
+				tokens = nil
+			}
+			i++
+			if c == '\n' {
+				line, lineStart = line+1, i
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		default:
+			t := patternToken{line: line, column: i - lineStart + 1}
+			end := i
+			if c == '[' {
+				for end++; end < len(pattern) && pattern[end] != ']' && pattern[end] != '\n'; end++ {
+					if pattern[end] == '\\' && end+1 < len(pattern) && pattern[end+1] != '\n' {
+						end++
+					}
+				}
+				if end == len(pattern) || pattern[end] != ']' {
+					return nil, t.errorf("the set is not closed")
+				}
+				end++
+			} else {
+				for end < len(pattern) && !strings.ContainsRune(" \t\r\n;#[", rune(pattern[end])) {
+					end++
+				}
+			}
+			t.text = pattern[i:end]
+			tokens = append(tokens, t)
+			i = end
+		}
+	}
+	if len(tokens) > 0 {
+		statements = append(statements, tokens)
+	}
+
+	return statements, nil
+}
+
+// patternParser parses the tokens of a statement, the first one is its keyword.
+type patternParser struct {
+	tokens []patternToken
+	pos    int
+}
+
+func (p *patternParser) parse(cond *ByteCondition) error {
+	keyword := p.tokens[0]
+	p.pos = 1
+
+	switch keyword.text {
+	case "len":
+		r, err := p.rangeArg()
+		if err != nil {
+			return err
+		}
+		cond.MinLength, cond.MaxLength = r.Min, r.Max
+	case "unit":
+		t, err := p.next("a unit")
+		if err != nil {
+			return err
+		}
+		unit, ok := tagUnits[t.text]
+		if !ok {
+			return t.errorf("unknown unit: " + t.text)
+		}
+		cond.LengthUnit = unit
+	case "once":
+		set, err := p.set()
+		if err != nil {
+			return err
+		}
+		cond.MayContainsOnce = set
+	case "adjacent":
+		r, err := p.adjacencyRule()
+		if err != nil {
+			return err
+		}
+		cond.Adjacency = append(cond.Adjacency, r)
+	case "count-each":
+		set, err := p.set()
+		if err != nil {
+			return err
+		}
+		r, err := p.rangeArg()
+		if err != nil {
+			return err
+		}
+		if cond.CharCount == nil {
+			cond.CharCount = map[byte]Range{}
+		}
+		for _, c := range set.Bytes() {
+			cond.CharCount[c] = r
+		}
+	case "count":
+		set, err := p.set()
+		if err != nil {
+			return err
+		}
+		r, err := p.rangeArg()
+		if err != nil {
+			return err
+		}
+		cond.ClassCounts = append(cond.ClassCounts, ClassCount{Set: set, Min: r.Min, Max: r.Max})
+	case "at":
+		t, err := p.next("an index")
+		if err != nil {
+			return err
+		}
+		i, err := strconv.Atoi(t.text)
+		if err != nil {
+			return t.errorf("the index: " + t.text + ", is not a number")
+		}
+		if i > maxPositionIndex || i < -maxPositionIndex-1 {
+			return keyword.errorf("the index: " + t.text + ", must be between " +
+				strconv.Itoa(-maxPositionIndex-1) + " and " + strconv.Itoa(maxPositionIndex))
+		}
+		set, err := p.set()
+		if err != nil {
+			return err
+		}
+		if cond.PositionSets == nil {
+			cond.PositionSets = map[int]CharSet{}
+		}
+		if old, ok := cond.PositionSets[i]; ok {
+			set = set.Intersect(old)
+		}
+		cond.PositionSets[i] = set
+	case "prefix-sets", "suffix-sets":
+		var sets []CharSet
+		for len(sets) == 0 || p.pos < len(p.tokens) {
+			set, err := p.set()
+			if err != nil {
+				return err
+			}
+			sets = append(sets, set)
+		}
+		if keyword.text == "prefix-sets" {
+			cond.PrefixSets = sets
+		} else {
+			cond.SuffixSets = sets
+		}
+	default:
+		if err := p.parseField(cond, keyword); err != nil {
+			return err
+		}
+	}
+
+	if p.pos < len(p.tokens) {
+		t := p.tokens[p.pos]
+		return t.errorf("unexpected: " + t.text + ", after the statement: " + keyword.text)
+	}
+
+	return nil
+}
+
+// parseField parses the statements of the patternSets, patternPairs and
+// patternInts tables.
+func (p *patternParser) parseField(cond *ByteCondition, keyword patternToken) error {
+	for _, s := range patternSets {
+		if s.keyword == keyword.text {
+			set, err := p.set()
+			if err != nil {
+				return err
+			}
+			*s.field(cond) = set
+			return nil
+		}
+	}
+	for _, s := range patternPairs {
+		if s.keyword == keyword.text {
+			chars, err := p.set()
+			if err != nil {
+				return err
+			}
+			if err := p.word("by"); err != nil {
+				return err
+			}
+			neighbors, err := p.set()
+			if err != nil {
+				return err
+			}
+			*s.field(cond) = append(*s.field(cond), [2]CharSet{chars, neighbors})
+			return nil
+		}
+	}
+	for _, s := range patternInts {
+		if s.keyword == keyword.text {
+			n, err := p.number()
+			if err != nil {
+				return err
+			}
+			*s.field(cond) = n
+			return nil
+		}
+	}
+
+	return keyword.errorf("unknown statement: " + keyword.text)
+}
+
+func (p *patternParser) adjacencyRule() (AdjacencyRule, error) {
+	chars, err := p.set()
+	if err != nil {
+		return AdjacencyRule{}, err
+	}
+	t, err := p.next("a direction")
+	if err != nil {
+		return AdjacencyRule{}, err
+	}
+	r := AdjacencyRule{Chars: chars}
+	for d, name := range patternDirections {
+		if name != "" && name == t.text {
+			r.Direction = Direction(d)
+		}
+	}
+	if r.Direction == 0 {
+		return AdjacencyRule{}, t.errorf("unknown direction: " + t.text)
+	}
+	if p.pos < len(p.tokens) && p.tokens[p.pos].text == "not" {
+		r.Not = true
+		p.pos++
+	}
+	if r.Neighbors, err = p.set(); err != nil {
+		return AdjacencyRule{}, err
+	}
+
+	return r, nil
+}
+
+// next returns the next token of the statement, what names it in the error
+// if the statement has no more tokens.
+func (p *patternParser) next(what string) (patternToken, error) {
+	if p.pos == len(p.tokens) {
+		last := p.tokens[p.pos-1]
+		last.column += len(last.text)
+		return patternToken{}, last.errorf("the statement: " + p.tokens[0].text + ", must be followed with " + what)
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+
+	return t, nil
+}
+
+func (p *patternParser) word(word string) error {
+	t, err := p.next(word)
+	if err != nil {
+		return err
+	}
+	if t.text != word {
+		return t.errorf("expected: " + word + ", found: " + t.text)
+	}
+
+	return nil
+}
+
+func (p *patternParser) set() (CharSet, error) {
+	t, err := p.next("a set")
+	if err != nil {
+		return CharSet{}, err
+	}
+	if t.text[0] != '[' {
+		return CharSet{}, t.errorf("the set: " + t.text + ", must be written in brackets")
+	}
+	set, err := ParseCharSet(t.text[1 : len(t.text)-1])
+	if err != nil {
+		return CharSet{}, t.errorf(err.Error())
+	}
+
+	return set, nil
+}
+
+func (p *patternParser) number() (int, error) {
+	t, err := p.next("a number")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(t.text)
+	if err != nil || n < 0 {
+		return 0, t.errorf("the number: " + t.text + ", must be a positive number")
+	}
+
+	return n, nil
+}
+
+// rangeArg parses a range, N is the range from N to N.
+func (p *patternParser) rangeArg() (Range, error) {
+	t, err := p.next("a range")
+	if err != nil {
+		return Range{}, err
+	}
+	lo, hi, isRange := strings.Cut(t.text, "..")
+	var r Range
+	if lo != "" {
+		r.Min, err = strconv.Atoi(lo)
+	}
+	if err == nil && hi != "" {
+		r.Max, err = strconv.Atoi(hi)
+	}
+	if !isRange {
+		r.Max = r.Min
+	}
+	if err != nil || r.Min < 0 || r.Max < 0 || (lo == "" && (!isRange || hi == "")) {
+		return Range{}, t.errorf("the range: " + t.text + ", must be written as N, N.., ..M or N..M")
+	}
+	if isRange && hi != "" && r.Min > r.Max {
+		return Range{}, t.errorf("the range: " + t.text + ", cannot have a min greater than the max")
+	}
+
+	return r, nil
+}
+
+// String returns the condition as a pattern that ParsePattern reads back into
+// the same condition. The CharCount of the chars above 127 can't be written
+// and is left out, and so are the Adjacency rules without a valid Direction.
+func (cond *ByteCondition) String() string {
+	if cond == nil {
+		return ""
+	}
+
+	var statements []string
+	add := func(parts ...string) {
+		statements = append(statements, strings.Join(parts, " "))
+	}
+	if cond.MinLength > 0 || cond.MaxLength > 0 {
+		add("len", patternRange(Range{Min: cond.MinLength, Max: cond.MaxLength}))
+	}
+	for name, unit := range tagUnits {
+		if unit == cond.LengthUnit {
+			add("unit", name)
+		}
+	}
+	for _, s := range patternSets {
+		if set := *s.field(cond); !set.IsEmpty() {
+			add(s.keyword, patternSet(set))
+		}
+	}
+	for _, s := range patternPairs {
+		for _, pair := range *s.field(cond) {
+			if !pair[0].IsEmpty() && !pair[1].IsEmpty() {
+				add(s.keyword, patternSet(pair[0]), "by", patternSet(pair[1]))
+			}
+		}
+	}
+	for _, r := range cond.Adjacency {
+		if r.Direction < DirectionNext || r.Direction > DirectionBoth {
+			continue
+		}
+		direction := patternDirections[r.Direction]
+		if r.Not {
+			direction += " not"
+		}
+		add("adjacent", patternSet(r.Chars), direction, patternSet(r.Neighbors))
+	}
+	if !cond.MayContainsOnce.IsEmpty() {
+		add("once", patternSet(cond.MayContainsOnce))
+	}
+	for _, s := range patternInts[:4] {
+		if n := *s.field(cond); n > 0 {
+			add(s.keyword, strconv.Itoa(n))
+		}
+	}
+	var counted CharSet
+	chars := sortedChars(cond.CharCount)
+	for _, c := range chars {
+		if counted.Contains(c) {
+			continue
+		}
+		r := cond.CharCount[c]
+		var set CharSet
+		for _, o := range chars {
+			if cond.CharCount[o] == r {
+				set.add(o)
+			}
+		}
+		counted = counted.Union(set)
+		add("count-each", patternSet(set), patternRange(r))
+	}
+	for _, cc := range cond.ClassCounts {
+		add("count", patternSet(cc.Set), patternRange(Range{Min: cc.Min, Max: cc.Max}))
+	}
+	for _, s := range patternInts[4:] {
+		if n := *s.field(cond); n > 0 {
+			add(s.keyword, strconv.Itoa(n))
+		}
+	}
+	indexes := make([]int, 0, len(cond.PositionSets))
+	for i := range cond.PositionSets {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		add("at", strconv.Itoa(i), patternSet(cond.PositionSets[i]))
+	}
+	for _, s := range [...]struct {
+		keyword string
+		sets    []CharSet
+	}{{"prefix-sets", cond.PrefixSets}, {"suffix-sets", cond.SuffixSets}} {
+		if len(s.sets) > 0 {
+			parts := []string{s.keyword}
+			for _, set := range s.sets {
+				parts = append(parts, patternSet(set))
+			}
+			add(parts...)
+		}
+	}
+
+	return strings.Join(statements, "; ")
+}
+
+// sortedChars returns the ASCII chars of the CharCount in ascending order.
+func sortedChars(counts map[byte]Range) []byte {
+	chars := make([]byte, 0, len(counts))
+	for c := range counts {
+		if c <= asciiMaxDec {
+			chars = append(chars, c)
+		}
+	}
+	sort.Slice(chars, func(i, j int) bool { return chars[i] < chars[j] })
+
+	return chars
+}
+
+func patternSet(set CharSet) string {
+	return "[" + set.String() + "]"
+}
+
+func patternRange(r Range) string {
+	switch {
+	case r.Min == r.Max:
+		return strconv.Itoa(r.Min)
+	case r.Max == 0:
+		return strconv.Itoa(r.Min) + ".."
+	case r.Min == 0:
+		return ".." + strconv.Itoa(r.Max)
+	}
+
+	return strconv.Itoa(r.Min) + ".." + strconv.Itoa(r.Max)
+}
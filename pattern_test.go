@@ -0,0 +1,126 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	cond, err := strgo.ParsePattern("len 3..20; only [a-zA-Z0-9_.]; once [_.]; surround [_.] by [a-zA-Z0-9]; no-prefix [_.]")
+	assert.Nil(t, err)
+	assert.Equal(t, &strgo.ByteCondition{
+		MinLength:             3,
+		MaxLength:             20,
+		OnlyContains:          strgo.MustParseCharSet("a-zA-Z0-9_."),
+		MayContainsOnce:       strgo.CharSetOf('_', '.'),
+		MustBeSurroundedBy:    [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+		MustNotContainsPrefix: strgo.CharSetOf('_', '.'),
+	}, cond)
+	assert.Nil(t, strgo.Byte("dali_kewara", cond))
+	assert.EqualError(t, strgo.Byte("_dali", cond), "the string must not contain prefix: _")
+
+	v, err := strgo.CompilePattern(`
+		# plate numbers like AB-1234-X
+		len 9
+		prefix-sets [A-Z] [A-Z] [\-]
+		at 3 [0-9]; at 4 [0-9]; at 5 [0-9]; at 6 [0-9]
+		suffix-sets [\-] [A-Z]
+	`)
+	assert.Nil(t, err)
+	assert.Nil(t, v.Validate("AB-1234-X"))
+	assert.True(t, errors.Is(v.Validate("AB-12x4-X"), strgo.ErrPositionSets))
+	assert.True(t, errors.Is(v.Validate("AB-1234-XY"), strgo.ErrMaxLength))
+
+	cond, err = strgo.ParsePattern("count [a-z] 2..; count-each [.-] ..1; count-each [_] 0..3; adjacent [\\-] both not [\\-]; repeat 2; unit runes")
+	assert.Nil(t, err)
+	assert.Equal(t, []strgo.ClassCount{{Set: strgo.LowerAlphabeticSet, Min: 2}}, cond.ClassCounts)
+	assert.Equal(t, map[byte]strgo.Range{'.': {Max: 1}, '-': {Max: 1}, '_': {Max: 3}}, cond.CharCount)
+	assert.Equal(t, []strgo.AdjacencyRule{{Chars: strgo.CharSetOf('-'), Neighbors: strgo.CharSetOf('-'), Direction: strgo.DirectionBoth, Not: true}}, cond.Adjacency)
+	assert.Equal(t, 2, cond.MaxRepeatRun)
+	assert.Equal(t, strgo.LengthRunes, cond.LengthUnit)
+
+	assert.Panics(t, func() { strgo.MustCompilePattern("only [a-z") })
+	assert.NotPanics(t, func() { strgo.MustCompilePattern("only [a-z]") })
+}
+
+func TestParsePattern_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		err     string
+	}{
+		{"lenght 3..20", "the pattern at line: 1, column: 1, unknown statement: lenght"},
+		{"len 3..20; only [a-z", "the pattern at line: 1, column: 17, the set is not closed"},
+		{"len 3..20\nonly a-z", "the pattern at line: 2, column: 6, the set: a-z, must be written in brackets"},
+		{"len 3..20\n  only [z-a]", "the pattern at line: 2, column: 8, the range: z-a, is reversed"},
+		{"len three", "the pattern at line: 1, column: 5, the range: three, must be written as N, N.., ..M or N..M"},
+		{"len ..", "the pattern at line: 1, column: 5, the range: .., must be written as N, N.., ..M or N..M"},
+		{"len 20..3", "the pattern at line: 1, column: 5, the range: 20..3, cannot have a min greater than the max"},
+		{"count [a] 3..1", "the pattern at line: 1, column: 11, the range: 3..1, cannot have a min greater than the max"},
+		{"only", "the pattern at line: 1, column: 5, the statement: only, must be followed with a set"},
+		{"surround [.] with [a-z]", "the pattern at line: 1, column: 14, expected: by, found: with"},
+		{"adjacent [.] around [a-z]", "the pattern at line: 1, column: 14, unknown direction: around"},
+		{"repeat -1", "the pattern at line: 1, column: 8, the number: -1, must be a positive number"},
+		{"unit words", "the pattern at line: 1, column: 6, unknown unit: words"},
+		{"at x [a]", "the pattern at line: 1, column: 4, the index: x, is not a number"},
+		{"len 3..20\nat 20000000 [a]", "the pattern at line: 2, column: 1, the index: 20000000, must be between -65536 and 65535"},
+		{"at -65537 [a]", "the pattern at line: 1, column: 1, the index: -65537, must be between -65536 and 65535"},
+		{"once [_.] [a]", "the pattern at line: 1, column: 11, unexpected: [a], after the statement: once"},
+	} {
+		_, err := strgo.ParsePattern(tc.pattern)
+		assert.EqualError(t, err, tc.err, tc.pattern)
+		var perr *strgo.PatternError
+		assert.True(t, errors.As(err, &perr), tc.pattern)
+	}
+}
+
+func TestByteCondition_String(t *testing.T) {
+	pattern := "len 3..20; only [a-zA-Z0-9_.]; no-prefix [_.]; surround [_.] by [a-zA-Z0-9]; once [_.]"
+	cond, err := strgo.ParsePattern("len 3..20; only [a-zA-Z0-9_.]; once [_.]; surround [_.] by [a-zA-Z0-9]; no-prefix [_.]")
+	assert.Nil(t, err)
+	assert.Equal(t, pattern, cond.String())
+
+	cond = &strgo.ByteCondition{
+		MinLength:                   8,
+		LengthUnit:                  strgo.LengthGraphemes,
+		MustBeFollowedBy:            [][2]strgo.CharSet{{strgo.CharSetOf('-'), strgo.NumericSet}, {strgo.CharSetOf('+'), strgo.CharSetOf('1')}},
+		MustNotBePrecededBy:         [][2]strgo.CharSet{{strgo.CharSetOf(']'), strgo.CharSetOf('[')}},
+		Adjacency:                   []strgo.AdjacencyRule{{Chars: strgo.CharSetOf('.'), Neighbors: strgo.NumericSet, Direction: strgo.DirectionPrevious}},
+		AtLeastHaveUpperLetterCount: 1,
+		AtLeastHaveSpecialCharCount: 2,
+		CharCount:                   map[byte]strgo.Range{'-': {Max: 2}, '.': {Max: 2}, '_': {Min: 1, Max: 1}},
+		ClassCounts:                 []strgo.ClassCount{{Set: strgo.NumericSet, Min: 2, Max: 4}},
+		MaxSequentialRun:            3,
+		PositionSets:                map[int]strgo.CharSet{-1: strgo.NumericSet, 0: strgo.UpperAlphabeticSet},
+		SuffixSets:                  []strgo.CharSet{strgo.CharSetOf('\\'), strgo.CharSetOf('\n')},
+	}
+	assert.Equal(t, `len 8..; unit graphemes; follow [\-] by [0-9]; follow [+] by [1]; not-precede [\]] by [\[]; `+
+		`adjacent [.] previous [0-9]; upper 1; special 2; count-each [\-.] ..2; count-each [_] 1; count [0-9] 2..4; `+
+		`sequence 3; at -1 [0-9]; at 0 [A-Z]; suffix-sets [\\] [\x0a]`, cond.String())
+	parsed, err := strgo.ParsePattern(cond.String())
+	assert.Nil(t, err)
+	assert.Equal(t, cond, parsed)
+
+	for _, preset := range []*strgo.ByteCondition{
+		strgo.UsernameCondition(3, 20),
+		strgo.EmailCondition(),
+		strgo.PasswordCondition(strgo.DefaultPasswordPolicy),
+	} {
+		parsed, err := strgo.ParsePattern(preset.String())
+		assert.Nil(t, err)
+		assert.Equal(t, preset.String(), parsed.String())
+	}
+
+	cond = &strgo.ByteCondition{Adjacency: []strgo.AdjacencyRule{
+		{Chars: strgo.CharSetOf('.'), Neighbors: strgo.NumericSet, Direction: strgo.Direction(7)},
+		{Chars: strgo.CharSetOf('-'), Neighbors: strgo.CharSetOf('-'), Direction: strgo.DirectionNext, Not: true},
+	}}
+	assert.Equal(t, `adjacent [\-] next not [\-]`, cond.String())
+	parsed, err = strgo.ParsePattern(cond.String())
+	assert.Nil(t, err)
+	assert.Equal(t, cond.Adjacency[1:], parsed.Adjacency)
+
+	assert.Equal(t, "", (&strgo.ByteCondition{}).String())
+	assert.Equal(t, "", (*strgo.ByteCondition)(nil).String())
+}
@@ -0,0 +1,73 @@
+package strgo
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// maxPositionIndex is the farthest a position set may be from its end of the
+// string. ValidateReader holds back as many bytes as the farthest set from the
+// end, so the limit bounds its buffer.
+const maxPositionIndex = 1<<16 - 1
+
+// positionSet is the set of the char at index, from the start of the string or
+// from its end.
+type positionSet struct {
+	index int
+	set   CharSet
+}
+
+// compilePositions merges the PositionSets, PrefixSets and SuffixSets of the
+// condition into the sets of the first chars and of the last ones, sorted by
+// index, the index of a back set is the index of its char from the end. A
+// position with many sets allows the chars they all have.
+func compilePositions(cond *ByteCondition) (front, back []positionSet, err error) {
+	for i, s := range cond.PositionSets {
+		if i > maxPositionIndex || i < -maxPositionIndex-1 {
+			return nil, nil, errors.New("the position index: " + strconv.Itoa(i) + ", must be between " +
+				strconv.Itoa(-maxPositionIndex-1) + " and " + strconv.Itoa(maxPositionIndex))
+		}
+		if i >= 0 {
+			front = append(front, positionSet{index: i, set: s.kept()})
+		} else {
+			back = append(back, positionSet{index: -i - 1, set: s.kept()})
+		}
+	}
+	if len(cond.PrefixSets) > maxPositionIndex+1 || len(cond.SuffixSets) > maxPositionIndex+1 {
+		return nil, nil, errors.New("the prefix and suffix sets cannot be more than " + strconv.Itoa(maxPositionIndex+1))
+	}
+	for i, s := range cond.PrefixSets {
+		front = append(front, positionSet{index: i, set: s.kept()})
+	}
+	for i, s := range cond.SuffixSets {
+		back = append(back, positionSet{index: len(cond.SuffixSets) - 1 - i, set: s.kept()})
+	}
+
+	return mergePositions(front), mergePositions(back), nil
+}
+
+// mergePositions sorts the sets by index and intersects the sets of the same
+// index.
+func mergePositions(sets []positionSet) []positionSet {
+	if len(sets) < 2 {
+		return sets
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].index < sets[j].index })
+	merged := sets[:0]
+	for _, p := range sets {
+		if n := len(merged); n > 0 && merged[n-1].index == p.index {
+			merged[n-1].set = merged[n-1].set.Intersect(p.set)
+			continue
+		}
+		merged = append(merged, p)
+	}
+
+	return merged
+}
+
+// positionError returns the violation of the char c at offset i, index is its
+// position, negative from the end.
+func positionError(c byte, offset, index int, set CharSet) *ValidationError {
+	return &ValidationError{Rule: RulePositionSets, Char: rune(c), Offset: offset, Index: index, Expected: string(set.Bytes())}
+}
@@ -0,0 +1,85 @@
+package strgo
+
+// PasswordPolicy is the policy of PasswordCondition. The counts are the minimum
+// number of chars of each class the password must have, zero means the class
+// isn't required.
+type PasswordPolicy struct {
+	MinLength    int
+	MaxLength    int
+	UpperLetters int
+	LowerLetters int
+	Numbers      int
+	SpecialChars int
+}
+
+// DefaultPasswordPolicy is the policy of the README password example: 6 to 32
+// chars, with at least one upper and lower case letter, one number and one
+// special char.
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:    6,
+	MaxLength:    32,
+	UpperLetters: 1,
+	LowerLetters: 1,
+	Numbers:      1,
+	SpecialChars: 1,
+}
+
+// UsernameCondition returns the condition of a username: alphanumeric chars,
+// underscores and periods, where the special chars must be surrounded by
+// alphanumeric chars and appear once.
+//
+// Every call returns a new condition, so it can be changed freely.
+func UsernameCondition(minLength, maxLength int) *ByteCondition {
+	return &ByteCondition{
+		MinLength:          minLength,
+		MaxLength:          maxLength,
+		OnlyContains:       AlphanumericSet.Union(CharSetOf('_', '.')),
+		MustBeSurroundedBy: [][2]CharSet{{CharSetOf('_', '.'), AlphanumericSet}},
+		MayContainsOnce:    CharSetOf('_', '.'),
+	}
+}
+
+// EmailCondition returns the condition of a simple email address: 4 to 255
+// alphanumeric chars and _.-@+, where the special chars must be surrounded by
+// alphanumeric chars, with exactly one @.
+//
+// Every call returns a new condition, so it can be changed freely.
+func EmailCondition() *ByteCondition {
+	return &ByteCondition{
+		MinLength:          4,
+		MaxLength:          255,
+		OnlyContains:       AlphanumericSet.Union(CharSetOf('_', '.', '@', '-', '+')),
+		MustBeSurroundedBy: [][2]CharSet{{CharSetOf('_', '.', '@', '-', '+'), AlphanumericSet}},
+		MustContainsOnce:   CharSetOf('@'),
+	}
+}
+
+// EmailLocalCondition returns the condition of the local part of an email
+// address, the part before the @: up to 64 alphanumeric chars and _.-+, where
+// the special chars must be surrounded by alphanumeric chars.
+//
+// Every call returns a new condition, so it can be changed freely.
+func EmailLocalCondition() *ByteCondition {
+	return &ByteCondition{
+		MinLength:          1,
+		MaxLength:          64,
+		OnlyContains:       AlphanumericSet.Union(CharSetOf('_', '.', '-', '+')),
+		MustBeSurroundedBy: [][2]CharSet{{CharSetOf('_', '.', '-', '+'), AlphanumericSet}},
+	}
+}
+
+// PasswordCondition returns the condition of a password of any printable
+// ASCII chars that follows the policy.
+//
+// Every call returns a new condition, so it can be changed freely.
+func PasswordCondition(policy PasswordPolicy) *ByteCondition {
+	return &ByteCondition{
+		MinLength:                   policy.MinLength,
+		MaxLength:                   policy.MaxLength,
+		OnlyContains:                CharsSet,
+		AtLeastHaveUpperLetterCount: policy.UpperLetters,
+		AtLeastHaveLowerLetterCount: policy.LowerLetters,
+		AtLeastHaveNumberCount:      policy.Numbers,
+		AtLeastHaveSpecialCharCount: policy.SpecialChars,
+	}
+}
@@ -0,0 +1,124 @@
+package strgo_test
+
+import (
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUsernameCondition(t *testing.T) {
+	tests := []struct {
+		text  string
+		valid bool
+	}{
+		{"johndoe", true},
+		{"john_doe", true},
+		{"john.doe", true},
+		{"johndoe123", true},
+		{"john.doe123", true},
+		{"john_doe123", true},
+		{"john_doe.123", true},
+		{"_johndoe", false},
+		{"__johndoe", false},
+		{".johndoe", false},
+		{"..johndoe", false},
+		{"johndoe_", false},
+		{"johndoe__", false},
+		{"johndoe.", false},
+		{"johndoe..", false},
+		{"john__doe", false},
+		{"john_.doe", false},
+		{"john..doe", false},
+		{"john._doe", false},
+		{"john@doe", false},
+		{"@johndoe", false},
+		{"johndoe@", false},
+		{"joh_nd_oe", false},
+		{"joh.nd.oe", false},
+		{"jo", false},
+		{"johndoe_johndoe_johndoe", false},
+	}
+	for _, tt := range tests {
+		err := strgo.Byte(tt.text, strgo.UsernameCondition(3, 20))
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+}
+
+func TestEmailCondition(t *testing.T) {
+	tests := []struct {
+		text  string
+		valid bool
+	}{
+		{"johndoe@email.com", true},
+		{"john_doe@email.com", true},
+		{"john_do.e@email.com", true},
+		{"john-doe@email.com", true},
+		{"johndoe@email", true},
+		{"johndoe123@email", true},
+		{"john+doe123@email", true},
+		{"johndoe123email", false},
+		{"johndoe123.email", false},
+		{"john@doe123@email", false},
+		{".johndoe123@email", false},
+		{"johndoe123@email.", false},
+		{"johndoe123@", false},
+		{"john_.doe123@email", false},
+		{"johndoe123.@email", false},
+	}
+	for _, tt := range tests {
+		err := strgo.Byte(tt.text, strgo.EmailCondition())
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+}
+
+func TestEmailLocalCondition(t *testing.T) {
+	tests := []struct {
+		text  string
+		valid bool
+	}{
+		{"johndoe", true},
+		{"john_do.e", true},
+		{"john+doe123", true},
+		{"j", true},
+		{"john@doe", false},
+		{".johndoe", false},
+		{"johndoe.", false},
+		{"john_.doe", false},
+		{"johndoejohndoejohndoejohndoejohndoejohndoejohndoejohndoejohndoe12", false},
+	}
+	for _, tt := range tests {
+		err := strgo.Byte(tt.text, strgo.EmailLocalCondition())
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+}
+
+func TestPasswordCondition(t *testing.T) {
+	tests := []struct {
+		text  string
+		valid bool
+	}{
+		{"J()hndoe123", true},
+		{"John_doe123", true},
+		{"johndoe", false},
+		{"johndoe123", false},
+		{"Johndoe123", false},
+		{"J_d1", false},
+	}
+	for _, tt := range tests {
+		err := strgo.Byte(tt.text, strgo.PasswordCondition(strgo.DefaultPasswordPolicy))
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+	cond := strgo.PasswordCondition(strgo.PasswordPolicy{MinLength: 8, Numbers: 2})
+	assert.Nil(t, strgo.Byte("johndoe12", cond))
+	assert.NotNil(t, strgo.Byte("johndoe1", cond))
+}
+
+func TestPresets_FreshCopy(t *testing.T) {
+	cond := strgo.UsernameCondition(3, 20)
+	cond.OnlyContains = strgo.CharSetOf('!')
+	cond.MustBeSurroundedBy[0][1] = strgo.CharSetOf('!')
+	cond.MayContainsOnce = cond.MayContainsOnce.Union(strgo.CharSetOf('-'))
+	assert.True(t, strgo.AlphanumericSet.Contains('a'))
+	assert.Nil(t, strgo.Byte("abc_d", strgo.UsernameCondition(3, 20)))
+	assert.NotSame(t, &strgo.EmailCondition().MustBeSurroundedBy[0], &strgo.EmailCondition().MustBeSurroundedBy[0])
+}
@@ -0,0 +1,130 @@
+package strgo
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+const readerChunkSize = 4096
+
+// ValidateReader matches the content of the reader based on the compiled
+// ByteCondition. The content is read in chunks and every rule is checked
+// incrementally, so it runs in constant memory whatever the size of the content.
+// A read error other than io.EOF is returned as is.
+//
+// The result is the same as Validate on the whole content, except for the
+// order of the violations: MaxLength is reported as soon as the content gets
+// too long, which stops the reading, and MinLength is only known at the end.
+func (v *ByteValidator) ValidateReader(r io.Reader) error {
+	errs := collector{}
+	if err := validateReader(v, r, &errs); err != nil {
+		return err
+	}
+
+	return errs.err()
+}
+
+func validateReader(v *ByteValidator, r io.Reader, errs *collector) error {
+	// The last bytes are held back until the next read, so a char split
+	// across two reads can be decoded, and the last chars of the content are
+	// known when the scan reaches them.
+	hold := utf8.UTFMax
+	if n := len(v.back); n > 0 && v.back[n-1].index >= hold {
+		hold = v.back[n-1].index + 1
+	}
+
+	var (
+		buf     = make([]byte, readerChunkSize+hold)
+		s       = v.newScan(errs)
+		length  = streamLength{unit: v.lengthUnit}
+		measure = v.minLength > 0 || v.maxLength > 0
+		tooLong bool
+		carry   int
+		base    int
+	)
+
+	for {
+		n, err := r.Read(buf[carry:])
+		if err != nil && err != io.EOF {
+			return err
+		}
+		final := err == io.EOF
+		data := buf[:carry+n]
+
+		limit := len(data)
+		if !final {
+			limit -= hold
+			if limit < 0 {
+				limit = 0
+			}
+		}
+
+		if measure {
+			length.add(data[:limit])
+			if !tooLong && v.maxLength > 0 && length.n > v.maxLength {
+				tooLong = true
+				if errs.add(&ValidationError{Rule: RuleMaxLength, Offset: -1, Limit: v.maxLength, Count: length.n, Unit: v.lengthUnit}) {
+					return nil
+				}
+			}
+		}
+
+		if final {
+			if base+len(data) == 0 {
+				errs.add(&ValidationError{Rule: RuleEmpty, Offset: -1})
+				return nil
+			}
+			if scanText(&s, data, base, limit, true) {
+				return nil
+			}
+			if measure && !tooLong && checkLengthOf(length.n, v.minLength, 0, v.lengthUnit, errs) {
+				return nil
+			}
+			s.finish()
+			return nil
+		}
+
+		if scanText(&s, data, base, limit, false) {
+			return nil
+		}
+		carry = copy(buf, data[limit:])
+		base += limit
+	}
+}
+
+// streamLength measures the length of a content read in chunks. A
+// ByteCondition only accepts ASCII, so the runes, grapheme clusters and
+// display width are counted for ASCII: a CRLF pair is one grapheme and the
+// control chars have no width. Non-ASCII chars count as one.
+type streamLength struct {
+	unit LengthUnit
+	n    int
+	cr   bool
+}
+
+func (l *streamLength) add(b []byte) {
+	switch l.unit {
+	case LengthDefault, LengthBytes:
+		l.n += len(b)
+		return
+	}
+
+	for _, c := range b {
+		if !utf8.RuneStart(c) {
+			continue
+		}
+		switch l.unit {
+		case LengthRunes:
+			l.n++
+		case LengthGraphemes:
+			if !l.cr || c != '\n' {
+				l.n++
+			}
+		case LengthDisplayWidth:
+			if c >= ' ' && c != asciiMaxDec {
+				l.n++
+			}
+		}
+		l.cr = c == '\r'
+	}
+}
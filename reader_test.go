@@ -0,0 +1,121 @@
+package strgo_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var readerConditions = []*strgo.ByteCondition{
+	{
+		OnlyContains:       strgo.AlphanumericSet.Union(strgo.CharSetOf('_', '.')),
+		MustBeSurroundedBy: [][2]strgo.CharSet{{strgo.CharSetOf('_', '.'), strgo.AlphanumericSet}},
+		MayContainsOnce:    strgo.CharSetOf('_', '.'),
+	},
+	{
+		OnlyContainsPrefix:    strgo.CharSetOf('a', 'b'),
+		OnlyContainsSuffix:    strgo.CharSetOf('a', '.'),
+		MustNotContainsPrefix: strgo.CharSetOf('b'),
+		MustNotContainsSuffix: strgo.CharSetOf('b'),
+		MustContainsOnce:      strgo.CharSetOf('a'),
+		MustNotContains:       strgo.CharSetOf('x'),
+	},
+	{
+		MustContains:                strgo.CharSetOf('b', '_'),
+		AtLeastHaveUpperLetterCount: 1,
+		AtLeastHaveNumberCount:      2,
+		AtLeastHaveSpecialCharCount: 1,
+	},
+	{
+		MustBeFollowedBy:    [][2]strgo.CharSet{{strgo.CharSetOf('_'), strgo.CharSetOf('a', 'b')}},
+		MustBePrecededBy:    [][2]strgo.CharSet{{strgo.CharSetOf('.'), strgo.CharSetOf('1')}},
+		MustNotBeFollowedBy: [][2]strgo.CharSet{{strgo.CharSetOf('a'), strgo.CharSetOf('A')}},
+		MustNotBePrecededBy: [][2]strgo.CharSet{{strgo.CharSetOf('x'), strgo.CharSetOf('x')}},
+		Adjacency: []strgo.AdjacencyRule{
+			{Chars: strgo.CharSetOf('b'), Neighbors: strgo.CharSetOf('a', 'A'), Direction: strgo.DirectionBoth},
+			{Chars: strgo.CharSetOf('1'), Neighbors: strgo.CharSetOf('1', '_'), Direction: strgo.DirectionBoth, Not: true},
+		},
+	},
+	{
+		PrefixSets:   []strgo.CharSet{strgo.CharSetOf('a', 'b', 'A'), strgo.CharRange(0, 127), strgo.CharSetOf('1', '_', 'x')},
+		PositionSets: map[int]strgo.CharSet{-7: strgo.CharSetOf('a', '1'), 9: strgo.CharSetOf('b', 'x')},
+		SuffixSets:   []strgo.CharSet{strgo.CharSetOf('a', 'b', '1', 'x'), strgo.CharRange(0, 127), strgo.CharSetOf('.', 'A', 'x'), strgo.CharSetOf('b', 'a')},
+	},
+	{
+		MaxRepeatRun:     1,
+		MaxSequentialRun: 2,
+		MaxSameClassRun:  2,
+	},
+}
+
+func TestByteValidator_ValidateReader(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", "b", "A", "1", "_", ".", "x", "é"}
+	for _, cond := range readerConditions {
+		v, err := strgo.CompileByte(cond)
+		assert.Nil(t, err)
+		for i := 0; i < 3000; i++ {
+			var sb strings.Builder
+			for j := r.Intn(12); j >= 0; j-- {
+				sb.WriteString(alphabet[r.Intn(len(alphabet))])
+			}
+			text := sb.String()
+			want := v.Validate(text)
+			assert.Equal(t, want, v.ValidateBytes([]byte(text)), text)
+			assert.Equal(t, want, v.ValidateReader(strings.NewReader(text)), text)
+			assert.Equal(t, want, v.ValidateReader(iotest.OneByteReader(strings.NewReader(text))), text)
+			assert.Equal(t, want, v.ValidateReader(iotest.HalfReader(strings.NewReader(text))), text)
+		}
+	}
+}
+
+func TestByteValidator_ValidateReaderLarge(t *testing.T) {
+	v, err := strgo.CompileByte(&strgo.ByteCondition{
+		OnlyContains:       strgo.AlphanumericSet,
+		OnlyContainsSuffix: strgo.CharSetOf('z'),
+		MustContainsOnce:   strgo.CharSetOf('Z'),
+	})
+	assert.Nil(t, err)
+	text := strings.Repeat("abcdefghij", 1<<20) + "Z" + "z"
+	assert.Nil(t, v.ValidateReader(strings.NewReader(text)))
+	assert.EqualError(t, v.ValidateReader(strings.NewReader(text+"a")), "the string cannot contain suffix char: a")
+	err = v.ValidateReader(strings.NewReader(text[:5000] + "-" + text[5000:]))
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleOnlyContains, verr.Rule)
+	assert.Equal(t, 5000, verr.Offset)
+}
+
+func TestByteValidator_ValidateReaderLength(t *testing.T) {
+	v, err := strgo.CompileByte(&strgo.ByteCondition{
+		MinLength: 3,
+		MaxLength: 10000,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.ValidateReader(strings.NewReader("abc")))
+	assert.EqualError(t, v.ValidateReader(strings.NewReader("ab")), "the string length cannot be less than 3")
+	assert.EqualError(t, v.ValidateReader(strings.NewReader(strings.Repeat("a", 10001))), "the string length cannot be more than 10000")
+	assert.EqualError(t, v.ValidateReader(strings.NewReader("")), "the string is empty")
+	v, err = strgo.CompileByte(&strgo.ByteCondition{
+		MaxLength:  3,
+		LengthUnit: strgo.LengthGraphemes,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.ValidateReader(iotest.OneByteReader(strings.NewReader("a\r\nb"))))
+	assert.EqualError(t, v.ValidateReader(strings.NewReader("a\r\nbc")), "the string length cannot be more than 3 graphemes")
+	assert.Nil(t, v.ValidateBytes([]byte("a\r\nb")))
+}
+
+func TestByteValidator_ValidateReaderError(t *testing.T) {
+	v, err := strgo.CompileByte(&strgo.ByteCondition{})
+	assert.Nil(t, err)
+	readErr := errors.New("read failed")
+	err = v.ValidateReader(io.MultiReader(bytes.NewReader([]byte("abc")), iotest.ErrReader(readErr)))
+	assert.Equal(t, readErr, err)
+}
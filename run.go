@@ -0,0 +1,97 @@
+package strgo
+
+// sequences are the runs of chars that MaxSequentialRun limits, read forward
+// or backward: the alphabet, the digits and the rows of a QWERTY keyboard. The
+// letters match in both cases.
+var sequences = [...]string{
+	"abcdefghijklmnopqrstuvwxyz",
+	"0123456789",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+}
+
+// sequenceNext are the chars that come after each char in a sequence.
+var sequenceNext = func() (next [asciiMaxDec + 1]CharSet) {
+	for _, seq := range sequences {
+		for i := 1; i < len(seq); i++ {
+			for _, a := range bothCases(seq[i-1]) {
+				for _, b := range bothCases(seq[i]) {
+					next[a].add(b)
+				}
+			}
+		}
+	}
+
+	return next
+}()
+
+// charClasses are the classes of MaxSameClassRun: the upper case letters, the
+// lower case letters, the digits and the other chars.
+var charClasses = func() (classes [asciiMaxDec + 1]uint8) {
+	for c := range classes {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			classes[c] = 1
+		case c >= 'a' && c <= 'z':
+			classes[c] = 2
+		case c >= '0' && c <= '9':
+			classes[c] = 3
+		}
+	}
+
+	return classes
+}()
+
+// bothCases returns the lower and the upper case of a letter, twice any other char.
+func bothCases(c byte) [2]byte {
+	if c >= 'a' && c <= 'z' {
+		return [2]byte{c, c - 'a' + 'A'}
+	}
+
+	return [2]byte{c, c}
+}
+
+// run tracks the runs that end with the char c at offset i, prev is the byte
+// before it. A run is reported once, by the char that makes it too long.
+func (s *byteScan) run(c, prev byte, i int) bool {
+	v := s.v
+	first := i == 0 || prev > asciiMaxDec
+	if v.maxRepeatRun > 0 {
+		s.repeatRun++
+		if first || c != prev {
+			s.repeatRun = 1
+		}
+		if s.repeatRun == v.maxRepeatRun+1 && s.add(&ValidationError{Rule: RuleMaxRepeatRun, Char: rune(c), Offset: i, Limit: v.maxRepeatRun, Count: s.repeatRun}) {
+			return true
+		}
+	}
+	if v.maxSequentialRun > 0 {
+		up := !first && sequenceNext[prev].Contains(c)
+		down := !first && sequenceNext[c].Contains(prev)
+		switch {
+		case up && s.sequenceDir > 0, down && s.sequenceDir < 0:
+			s.sequenceRun++
+		case up:
+			s.sequenceRun, s.sequenceDir = 2, 1
+		case down:
+			s.sequenceRun, s.sequenceDir = 2, -1
+		default:
+			s.sequenceRun, s.sequenceDir = 1, 0
+		}
+		if s.sequenceRun == v.maxSequentialRun+1 && s.add(&ValidationError{Rule: RuleMaxSequentialRun, Char: rune(c), Offset: i, Limit: v.maxSequentialRun, Count: s.sequenceRun}) {
+			return true
+		}
+	}
+	if v.maxSameClassRun > 0 {
+		s.classRun++
+		if first || charClasses[c] != charClasses[prev] {
+			s.classRun = 1
+		}
+		if s.classRun == v.maxSameClassRun+1 && s.add(&ValidationError{Rule: RuleMaxSameClassRun, Char: rune(c), Offset: i, Limit: v.maxSameClassRun, Count: s.classRun}) {
+			return true
+		}
+	}
+
+	return false
+}
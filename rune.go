@@ -0,0 +1,395 @@
+package strgo
+
+import (
+	"errors"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneSet is a set of Unicode characters, made of single runes and Unicode
+// range tables, for example unicode.Latin or unicode.L.
+type RuneSet struct {
+	Runes  []rune
+	Tables []*unicode.RangeTable
+}
+
+type RuneCondition struct {
+	MinLength                   int
+	MaxLength                   int
+	LengthUnit                  LengthUnit
+	OnlyContains                RuneSet
+	OnlyContainsPrefix          RuneSet
+	OnlyContainsSuffix          RuneSet
+	MustContains                []rune
+	MustContainsOnce            []rune
+	MustNotContains             RuneSet
+	MustNotContainsPrefix       RuneSet
+	MustNotContainsSuffix       RuneSet
+	MustBeFollowedBy            [][2]RuneSet
+	MustBePrecededBy            [][2]RuneSet
+	MustBeSurroundedBy          [][2]RuneSet
+	MustNotBeFollowedBy         [][2]RuneSet
+	MustNotBePrecededBy         [][2]RuneSet
+	MayContainsOnce             []rune
+	AtLeastHaveUpperLetterCount int
+	AtLeastHaveLowerLetterCount int
+	AtLeastHaveNumberCount      int
+	AtLeastHaveSpecialCharCount int
+}
+
+// RuneValidator is a compiled RuneCondition.
+// A RuneValidator is immutable and safe for concurrent use by multiple goroutines.
+type RuneValidator struct {
+	minLength                   int
+	maxLength                   int
+	lengthUnit                  LengthUnit
+	onlyContains                runeSet
+	onlyContainsPrefix          runeSet
+	onlyContainsSuffix          runeSet
+	mustNotContains             runeSet
+	mustNotContainsPrefix       runeSet
+	mustNotContainsSuffix       runeSet
+	adjacency                   []runeAdjacencyRule
+	mustContains                []rune
+	mustContainsOnce            map[rune]bool
+	counted                     map[rune]int
+	onceLimited                 map[rune]bool
+	atLeastHaveUpperLetterCount int
+	atLeastHaveLowerLetterCount int
+	atLeastHaveNumberCount      int
+	atLeastHaveSpecialCharCount int
+}
+
+// runeSet is the compiled form of a RuneSet, with a bitmap for the ASCII runes.
+type runeSet struct {
+	set    bool
+	ascii  CharSet
+	runes  map[rune]struct{}
+	tables []*unicode.RangeTable
+}
+
+func newRuneSet(s RuneSet) runeSet {
+	rs := runeSet{
+		set:    s.Runes != nil || s.Tables != nil,
+		tables: append([]*unicode.RangeTable(nil), s.Tables...),
+	}
+	for _, r := range s.Runes {
+		if r >= 0 && r <= asciiMaxDec {
+			rs.ascii.add(byte(r))
+			continue
+		}
+		if rs.runes == nil {
+			rs.runes = map[rune]struct{}{}
+		}
+		rs.runes[r] = struct{}{}
+	}
+
+	return rs
+}
+
+func (s *runeSet) contains(r rune) bool {
+	if r >= 0 && r <= asciiMaxDec && s.ascii.Contains(byte(r)) {
+		return true
+	}
+	if _, ok := s.runes[r]; ok {
+		return true
+	}
+
+	return len(s.tables) > 0 && unicode.IsOneOf(s.tables, r)
+}
+
+// Rune matches the string based on the RuneCondition.
+// If one doesn't match, it will return a *ValidationError.
+// Unlike Byte, this function validates any Unicode character, the string must be
+// valid UTF-8. MinLength and MaxLength count runes by default, and the letter and number
+// counts use unicode.IsUpper, unicode.IsLower and unicode.IsDigit.
+//
+// Rune compiles the condition on every call. Use CompileRune to validate many
+// strings with the same condition.
+func Rune(text string, cond *RuneCondition) error {
+	v, err := CompileRune(cond)
+	if err != nil {
+		return err
+	}
+
+	return v.Validate(text)
+}
+
+// CompileRune builds the lookup sets of the RuneCondition once and returns a
+// RuneValidator. The condition is only read, later changes to it don't affect
+// the returned validator.
+func CompileRune(cond *RuneCondition) (*RuneValidator, error) {
+	if cond == nil {
+		return nil, errors.New("the condition is nil")
+	}
+
+	v := &RuneValidator{
+		minLength:                   cond.MinLength,
+		maxLength:                   cond.MaxLength,
+		lengthUnit:                  cond.LengthUnit.orDefault(LengthRunes),
+		onlyContains:                newRuneSet(cond.OnlyContains),
+		onlyContainsPrefix:          newRuneSet(cond.OnlyContainsPrefix),
+		onlyContainsSuffix:          newRuneSet(cond.OnlyContainsSuffix),
+		mustNotContains:             newRuneSet(cond.MustNotContains),
+		mustNotContainsPrefix:       newRuneSet(cond.MustNotContainsPrefix),
+		mustNotContainsSuffix:       newRuneSet(cond.MustNotContainsSuffix),
+		atLeastHaveUpperLetterCount: cond.AtLeastHaveUpperLetterCount,
+		atLeastHaveLowerLetterCount: cond.AtLeastHaveLowerLetterCount,
+		atLeastHaveNumberCount:      cond.AtLeastHaveNumberCount,
+		atLeastHaveSpecialCharCount: cond.AtLeastHaveSpecialCharCount,
+	}
+	v.adjacency = compileRuneAdjacency(cond)
+
+	// Every rune of MustContains, MustContainsOnce and MayContainsOnce gets a
+	// counter slot.
+	v.counted = map[rune]int{}
+	slot := func(r rune) {
+		if _, ok := v.counted[r]; !ok {
+			v.counted[r] = len(v.counted)
+		}
+	}
+	v.mustContainsOnce = map[rune]bool{}
+	v.onceLimited = map[rune]bool{}
+	for _, r := range cond.MustContains {
+		slot(r)
+		v.mustContains = append(v.mustContains, r)
+	}
+	for _, r := range cond.MustContainsOnce {
+		slot(r)
+		v.mustContains = append(v.mustContains, r)
+		v.mustContainsOnce[r] = true
+		v.onceLimited[r] = true
+	}
+	for _, r := range cond.MayContainsOnce {
+		slot(r)
+		v.onceLimited[r] = true
+	}
+
+	return v, nil
+}
+
+// Validate matches the string based on the compiled RuneCondition.
+// If one doesn't match, it will return a *ValidationError.
+func (v *RuneValidator) Validate(text string) error {
+	errs := collector{}
+	v.validate(text, &errs)
+
+	return errs.err()
+}
+
+// ValidateAll matches the string based on the compiled RuneCondition like
+// Validate, but it doesn't stop at the first violated rule. Every violation is
+// returned as ValidationErrors, ordered by their offset.
+func (v *RuneValidator) ValidateAll(text string) error {
+	errs := collector{all: true}
+	v.validate(text, &errs)
+
+	return errs.err()
+}
+
+func (v *RuneValidator) validate(text string, errs *collector) {
+	if text == "" {
+		errs.add(&ValidationError{Rule: RuleEmpty, Offset: -1})
+		return
+	}
+
+	if checkLength(text, v.minLength, v.maxLength, v.lengthUnit, errs) {
+		return
+	}
+
+	var (
+		counts                      []int
+		atLeastHaveUpperLetterCount = v.atLeastHaveUpperLetterCount
+		atLeastHaveLowerLetterCount = v.atLeastHaveLowerLetterCount
+		atLeastHaveNumberCount      = v.atLeastHaveNumberCount
+		atLeastHaveSpecialCharCount = v.atLeastHaveSpecialCharCount
+		prev                        = rune(-1)
+	)
+	if len(v.counted) > 0 {
+		counts = make([]int, len(v.counted))
+	}
+
+	for i := 0; i < len(text); {
+		c, size := utf8.DecodeRuneInString(text[i:])
+		next := i + size
+		if c == utf8.RuneError && size == 1 {
+			if errs.add(&ValidationError{Rule: RuleNotUTF8, Char: c, Offset: i}) {
+				return
+			}
+			prev, i = -1, next
+			continue
+		}
+		if i == 0 {
+			if v.onlyContainsPrefix.set && !v.onlyContainsPrefix.contains(c) && errs.add(&ValidationError{Rule: RuleOnlyContainsPrefix, Char: c, Offset: i}) {
+				return
+			}
+			if v.mustNotContainsPrefix.set && v.mustNotContainsPrefix.contains(c) && errs.add(&ValidationError{Rule: RuleMustNotContainsPrefix, Char: c, Offset: i}) {
+				return
+			}
+		}
+		if next == len(text) {
+			if v.onlyContainsSuffix.set && !v.onlyContainsSuffix.contains(c) && errs.add(&ValidationError{Rule: RuleOnlyContainsSuffix, Char: c, Offset: i}) {
+				return
+			}
+			if v.mustNotContainsSuffix.set && v.mustNotContainsSuffix.contains(c) && errs.add(&ValidationError{Rule: RuleMustNotContainsSuffix, Char: c, Offset: i}) {
+				return
+			}
+		}
+		if v.onlyContains.set && !v.onlyContains.contains(c) && errs.add(&ValidationError{Rule: RuleOnlyContains, Char: c, Offset: i}) {
+			return
+		}
+		if v.mustNotContains.set && v.mustNotContains.contains(c) && errs.add(&ValidationError{Rule: RuleMustNotContains, Char: c, Offset: i}) {
+			return
+		}
+		if counts != nil {
+			if s, ok := v.counted[c]; ok {
+				counts[s]++
+				if counts[s] > 1 && v.onceLimited[c] && errs.add(v.onceError(c, i)) {
+					return
+				}
+			}
+		}
+		if v.adjacency != nil {
+			if err := v.adjacencyError(c, i, prev, text[next:]); err != nil && errs.add(err) {
+				return
+			}
+		}
+		if atLeastHaveUpperLetterCount > 0 && unicode.IsUpper(c) {
+			atLeastHaveUpperLetterCount -= 1
+		}
+		if atLeastHaveLowerLetterCount > 0 && unicode.IsLower(c) {
+			atLeastHaveLowerLetterCount -= 1
+		}
+		if atLeastHaveNumberCount > 0 && unicode.IsDigit(c) {
+			atLeastHaveNumberCount -= 1
+		}
+		if atLeastHaveSpecialCharCount > 0 && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			atLeastHaveSpecialCharCount -= 1
+		}
+		prev, i = c, next
+	}
+	for _, r := range v.mustContains {
+		if counts[v.counted[r]] == 0 {
+			rule := RuleMustContains
+			if v.mustContainsOnce[r] {
+				rule = RuleMustContainsOnce
+			}
+			if errs.add(&ValidationError{Rule: rule, Char: r, Offset: -1, Limit: 1}) {
+				return
+			}
+		}
+	}
+	if atLeastHaveUpperLetterCount > 0 && errs.add(atLeastError(RuleAtLeastHaveUpperLetterCount, v.atLeastHaveUpperLetterCount, atLeastHaveUpperLetterCount)) {
+		return
+	}
+	if atLeastHaveLowerLetterCount > 0 && errs.add(atLeastError(RuleAtLeastHaveLowerLetterCount, v.atLeastHaveLowerLetterCount, atLeastHaveLowerLetterCount)) {
+		return
+	}
+	if atLeastHaveNumberCount > 0 && errs.add(atLeastError(RuleAtLeastHaveNumberCount, v.atLeastHaveNumberCount, atLeastHaveNumberCount)) {
+		return
+	}
+	if atLeastHaveSpecialCharCount > 0 && errs.add(atLeastError(RuleAtLeastHaveSpecialCharCount, v.atLeastHaveSpecialCharCount, atLeastHaveSpecialCharCount)) {
+		return
+	}
+}
+
+func (v *RuneValidator) onceError(c rune, offset int) *ValidationError {
+	rule := RuleMayContainsOnce
+	if v.mustContainsOnce[c] {
+		rule = RuleMustContainsOnce
+	}
+
+	return &ValidationError{Rule: rule, Char: c, Offset: offset, Limit: 1, Count: 2}
+}
+
+func atLeastError(rule Rule, limit, left int) *ValidationError {
+	return &ValidationError{Rule: rule, Offset: -1, Limit: limit, Count: limit - left}
+}
+
+// runeAdjacencyRule is a compiled rule pair of the MustBe*By and MustNotBe*By
+// fields of a RuneCondition, like adjacencyRule for a ByteCondition. expected
+// lists the neighbours for the error, it's empty when they have tables.
+type runeAdjacencyRule struct {
+	rule      Rule
+	index     int
+	chars     runeSet
+	neighbors runeSet
+	expected  string
+	prev      bool
+	next      bool
+	not       bool
+}
+
+// compileRuneAdjacency returns the rule pairs of the condition in the order of
+// the fields. A pair without chars or without neighbours is skipped.
+func compileRuneAdjacency(cond *RuneCondition) []runeAdjacencyRule {
+	var rules []runeAdjacencyRule
+	for _, field := range [...]struct {
+		rule  Rule
+		pairs [][2]RuneSet
+		prev  bool
+		next  bool
+		not   bool
+	}{
+		{rule: RuleMustBeFollowedBy, pairs: cond.MustBeFollowedBy, next: true},
+		{rule: RuleMustBePrecededBy, pairs: cond.MustBePrecededBy, prev: true},
+		{rule: RuleMustBeSurroundedBy, pairs: cond.MustBeSurroundedBy, prev: true, next: true},
+		{rule: RuleMustNotBeFollowedBy, pairs: cond.MustNotBeFollowedBy, next: true, not: true},
+		{rule: RuleMustNotBePrecededBy, pairs: cond.MustNotBePrecededBy, prev: true, not: true},
+	} {
+		for i, pair := range field.pairs {
+			r := runeAdjacencyRule{
+				rule:      field.rule,
+				index:     i,
+				chars:     newRuneSet(pair[0]),
+				neighbors: newRuneSet(pair[1]),
+				prev:      field.prev,
+				next:      field.next,
+				not:       field.not,
+			}
+			if !r.chars.set || !r.neighbors.set {
+				continue
+			}
+			// The tables can't be listed, the message falls back to the
+			// allowed characters when the neighbours have some.
+			if pair[1].Tables == nil {
+				r.expected = string(pair[1].Runes)
+			}
+			rules = append(rules, r)
+		}
+	}
+
+	return rules
+}
+
+// fails tells if the rule is violated by the neighbour n on one of its sides,
+// n is -1 for the edge of the string or an invalid char.
+func (r *runeAdjacencyRule) fails(n rune) bool {
+	if r.not {
+		return n >= 0 && r.neighbors.contains(n)
+	}
+
+	return n < 0 || !r.neighbors.contains(n)
+}
+
+// adjacencyError returns the violation of the first rule of the char c that
+// its previous neighbour prev fails, or else its next neighbour, the first
+// rune of rest. It returns nil if c doesn't violate any rule.
+func (v *RuneValidator) adjacencyError(c rune, offset int, prev rune, rest string) *ValidationError {
+	for k := range v.adjacency {
+		if r := &v.adjacency[k]; r.prev && r.chars.contains(c) && r.fails(prev) {
+			return &ValidationError{Rule: r.rule, Char: c, Offset: offset, Index: r.index, Expected: r.expected}
+		}
+	}
+	next := rune(-1)
+	if n, size := utf8.DecodeRuneInString(rest); size > 1 || n != utf8.RuneError {
+		next = n
+	}
+	for k := range v.adjacency {
+		if r := &v.adjacency[k]; r.next && r.chars.contains(c) && r.fails(next) {
+			return &ValidationError{Rule: r.rule, Char: c, Offset: offset, Index: r.index, Expected: r.expected}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"unicode"
+)
+
+func TestRune_MinLength(t *testing.T) {
+	err := strgo.Rune("José", &strgo.RuneCondition{
+		MinLength: 4,
+	})
+	assert.Nil(t, err)
+	err = strgo.Rune("José", &strgo.RuneCondition{
+		MinLength: 5,
+	})
+	assert.EqualError(t, err, "the string length cannot be less than 5 runes")
+}
+
+func TestRune_MaxLength(t *testing.T) {
+	err := strgo.Rune("Zoë", &strgo.RuneCondition{
+		MaxLength: 3,
+	})
+	assert.Nil(t, err)
+	err = strgo.Rune("Zoë", &strgo.RuneCondition{
+		MaxLength: 2,
+	})
+	assert.EqualError(t, err, "the string length cannot be more than 2 runes")
+}
+
+func TestRune_OnlyContains(t *testing.T) {
+	err := strgo.Rune("José Müller", &strgo.RuneCondition{
+		OnlyContains: strgo.RuneSet{Runes: []rune{' ', '\''}, Tables: []*unicode.RangeTable{unicode.L}},
+	})
+	assert.Nil(t, err)
+	err = strgo.Rune("José_Müller", &strgo.RuneCondition{
+		OnlyContains: strgo.RuneSet{Runes: []rune{' ', '\''}, Tables: []*unicode.RangeTable{unicode.L}},
+	})
+	assert.EqualError(t, err, "the string cannot contain char: _")
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, 5, verr.Offset)
+}
+
+func TestRune_PrefixSuffix(t *testing.T) {
+	cond := &strgo.RuneCondition{
+		OnlyContainsPrefix:    strgo.RuneSet{Tables: []*unicode.RangeTable{unicode.Lu}},
+		MustNotContainsSuffix: strgo.RuneSet{Runes: []rune{' ', '-'}},
+	}
+	assert.Nil(t, strgo.Rune("Élodie", cond))
+	assert.EqualError(t, strgo.Rune("élodie", cond), "the string cannot contain prefix char: é")
+	assert.EqualError(t, strgo.Rune("Élodie-", cond), "the string must not contain suffix: -")
+	cond = &strgo.RuneCondition{
+		OnlyContainsSuffix:    strgo.RuneSet{Runes: []rune{'ë'}},
+		MustNotContainsPrefix: strgo.RuneSet{Runes: []rune{'z'}},
+	}
+	assert.Nil(t, strgo.Rune("Zoë", cond))
+	assert.EqualError(t, strgo.Rune("Zoe", cond), "the string cannot contain suffix char: e")
+	assert.EqualError(t, strgo.Rune("zoë", cond), "the string must not contain prefix: z")
+}
+
+func TestRune_MustContains(t *testing.T) {
+	err := strgo.Rune("Zoë", &strgo.RuneCondition{
+		MustContains:     []rune{'ë'},
+		MustNotContains:  strgo.RuneSet{Tables: []*unicode.RangeTable{unicode.Nd}},
+		MustContainsOnce: []rune{'Z'},
+	})
+	assert.Nil(t, err)
+	err = strgo.Rune("Zoe", &strgo.RuneCondition{
+		MustContains: []rune{'ë'},
+	})
+	assert.EqualError(t, err, "the string must contain char: ë")
+	err = strgo.Rune("Zoë2", &strgo.RuneCondition{
+		MustNotContains: strgo.RuneSet{Tables: []*unicode.RangeTable{unicode.Nd}},
+	})
+	assert.EqualError(t, err, "the string must not contain char: 2")
+	err = strgo.Rune("ZoëZ", &strgo.RuneCondition{
+		MustContainsOnce: []rune{'Z'},
+	})
+	assert.EqualError(t, err, "the char: Z, must be appeared once in the string")
+}
+
+func TestRune_MayContainsOnce(t *testing.T) {
+	err := strgo.Rune("Jean-Luc", &strgo.RuneCondition{
+		MayContainsOnce: []rune{'-', '·'},
+	})
+	assert.Nil(t, err)
+	err = strgo.Rune("Jean·Luc·Marie", &strgo.RuneCondition{
+		MayContainsOnce: []rune{'-', '·'},
+	})
+	assert.EqualError(t, err, "the char: ·, must be appeared once in the string")
+}
+
+func TestRune_MustBeSurroundedBy(t *testing.T) {
+	cond := &strgo.RuneCondition{
+		MustBeSurroundedBy: [][2]strgo.RuneSet{{{Runes: []rune{'-', ' '}}, {Tables: []*unicode.RangeTable{unicode.L}}}},
+	}
+	assert.Nil(t, strgo.Rune("Jean-Luc Æsir", cond))
+	assert.EqualError(t, strgo.Rune("Jean--Luc", cond), "the char: -, must be surrounded with at least one of the allowed characters")
+	assert.NotNil(t, strgo.Rune("-Jean", cond))
+	assert.NotNil(t, strgo.Rune("Jean ", cond))
+
+	cond.MustBeSurroundedBy[0][1].Runes = []rune{'1'}
+	err := strgo.Rune("Jean--Luc", cond)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "", verr.Expected)
+	assert.EqualError(t, err, "the char: -, must be surrounded with at least one of the allowed characters")
+	cond.MustBeSurroundedBy[0][1] = strgo.RuneSet{Runes: []rune{'a', 'b'}}
+	assert.EqualError(t, strgo.Rune("a-c", cond), "the char: -, must be surrounded with at least one of these characters: ab")
+}
+
+func TestRune_MustBeFollowedBy(t *testing.T) {
+	cond := &strgo.RuneCondition{
+		MustBeFollowedBy: [][2]strgo.RuneSet{
+			{{Runes: []rune{'·'}}, {Tables: []*unicode.RangeTable{unicode.Lu}}},
+			{{Runes: []rune{'-'}}, {Runes: []rune{'é', 'è'}}},
+		},
+	}
+	assert.Nil(t, strgo.Rune("Jean·Luc-é", cond))
+	assert.EqualError(t, strgo.Rune("Jean·luc", cond), "the char: ·, must be followed with at least one of the allowed characters")
+	err := strgo.Rune("Jean-e", cond)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleMustBeFollowedBy, verr.Rule)
+	assert.Equal(t, 1, verr.Index)
+	assert.Equal(t, 4, verr.Offset)
+	assert.EqualError(t, err, "the char: -, must be followed with at least one of these characters: éè")
+	assert.True(t, errors.Is(strgo.Rune("Jean-", cond), strgo.ErrMustBeFollowedBy))
+	assert.True(t, errors.Is(strgo.Rune("Jean-\xff", cond), strgo.ErrMustBeFollowedBy))
+}
+
+func TestRune_MustBePrecededBy(t *testing.T) {
+	cond := &strgo.RuneCondition{
+		MustBePrecededBy: [][2]strgo.RuneSet{{{Runes: []rune{'ª', 'º'}}, {Tables: []*unicode.RangeTable{unicode.Nd}}}},
+	}
+	assert.Nil(t, strgo.Rune("1ª 2º", cond))
+	assert.EqualError(t, strgo.Rune("ª", cond), "the char: ª, must be preceded with at least one of the allowed characters")
+	assert.True(t, errors.Is(strgo.Rune("1ª xº", cond), strgo.ErrMustBePrecededBy))
+}
+
+func TestRune_MustNotBeFollowedBy(t *testing.T) {
+	cond := &strgo.RuneCondition{
+		MustNotBeFollowedBy: [][2]strgo.RuneSet{{{Runes: []rune{'·', '-'}}, {Runes: []rune{'·', '-'}}}},
+		MustNotBePrecededBy: [][2]strgo.RuneSet{{{Runes: []rune{'·'}}, {Runes: []rune{'_'}}}},
+	}
+	assert.Nil(t, strgo.Rune("-Jean·Luc-", cond))
+	assert.EqualError(t, strgo.Rune("Jean·-Luc", cond), "the char: ·, must not be followed with any of these characters: ·-")
+	err := strgo.Rune("Jean_·Luc", cond)
+	assert.True(t, errors.Is(err, strgo.ErrMustNotBePrecededBy))
+	assert.EqualError(t, err, "the char: ·, must not be preceded with any of these characters: _")
+}
+
+func TestRune_AtLeastHaveCount(t *testing.T) {
+	cond := &strgo.RuneCondition{
+		AtLeastHaveUpperLetterCount: 1,
+		AtLeastHaveLowerLetterCount: 1,
+		AtLeastHaveNumberCount:      1,
+		AtLeastHaveSpecialCharCount: 1,
+	}
+	assert.Nil(t, strgo.Rune("Ñandú1!", cond))
+	assert.EqualError(t, strgo.Rune("ñandú1!", cond), "the string must have at least 1 upper case letter(s)")
+	assert.EqualError(t, strgo.Rune("ÑANDÚ1!", cond), "the string must have at least 1 lower case letter(s)")
+	assert.EqualError(t, strgo.Rune("Ñandú!", cond), "the string must have at least 1 number(s)")
+	assert.EqualError(t, strgo.Rune("Ñandú1", cond), "the string must have at least 1 special char(s)")
+}
+
+func TestRune_NotUTF8(t *testing.T) {
+	err := strgo.Rune("Zo\xffë", &strgo.RuneCondition{})
+	assert.True(t, errors.Is(err, strgo.ErrNotUTF8))
+	assert.EqualError(t, err, "the string has an invalid utf-8 char at offset: 2")
+}
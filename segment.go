@@ -0,0 +1,192 @@
+package strgo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// SegmentCondition splits the string by the Separator and validates each
+// segment with its own condition: the i-th segment with Segments[i], and the
+// segments without a condition, or with a nil one, with Default. A segment
+// without any condition is not validated.
+type SegmentCondition struct {
+	Separator   byte
+	MinSegments int
+	MaxSegments int
+	Segments    []*ByteCondition
+	Default     *ByteCondition
+}
+
+// SegmentError is returned when a segment is invalid. It unwraps to the error
+// of the segment, which is a *ValidationError with an offset in the segment.
+type SegmentError struct {
+	Index   int
+	Segment string
+	// Offset is the byte offset of the segment in the string.
+	Offset int
+	Err    error
+}
+
+func (e *SegmentError) Error() string {
+	return "the segment at index " + strconv.Itoa(e.Index) + " (" + strconv.Quote(e.Segment) + ") is invalid: " + e.Err.Error()
+}
+
+func (e *SegmentError) Unwrap() error {
+	return e.Err
+}
+
+// SegmentErrors is the list of invalid segments returned by
+// SegmentValidator.ValidateAll, in the order of the segments.
+type SegmentErrors []*SegmentError
+
+// Error returns the messages of all invalid segments, one per line.
+func (e SegmentErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the invalid segments, so errors.Is and errors.As look into each of them.
+func (e SegmentErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, v := range e {
+		errs[i] = v
+	}
+
+	return errs
+}
+
+// SegmentValidator is a compiled SegmentCondition.
+// A SegmentValidator is immutable and safe for concurrent use by multiple goroutines.
+type SegmentValidator struct {
+	separator   byte
+	minSegments int
+	maxSegments int
+	segments    []*ByteValidator
+	def         *ByteValidator
+}
+
+// Segment matches the string based on the SegmentCondition.
+// If a segment doesn't match, it will return a *SegmentError.
+//
+// Segment compiles the condition on every call. Use CompileSegment to validate
+// many strings with the same condition.
+func Segment(text string, cond *SegmentCondition) error {
+	v, err := CompileSegment(cond)
+	if err != nil {
+		return err
+	}
+
+	return v.Validate(text)
+}
+
+// CompileSegment compiles the condition of every segment once and returns a
+// SegmentValidator. The condition is only read, later changes to it don't
+// affect the returned validator.
+func CompileSegment(cond *SegmentCondition) (*SegmentValidator, error) {
+	if cond == nil {
+		return nil, errors.New("the condition is nil")
+	}
+	if cond.Separator == 0 || cond.Separator > asciiMaxDec {
+		return nil, errors.New("the separator must be an ascii char")
+	}
+
+	v := &SegmentValidator{
+		separator:   cond.Separator,
+		minSegments: cond.MinSegments,
+		maxSegments: cond.MaxSegments,
+		segments:    make([]*ByteValidator, len(cond.Segments)),
+	}
+	for i, c := range cond.Segments {
+		if c == nil {
+			continue
+		}
+		sv, err := CompileByte(c)
+		if err != nil {
+			return nil, errors.New("the segment condition at index " + strconv.Itoa(i) + " is invalid: " + err.Error())
+		}
+		v.segments[i] = sv
+	}
+	if cond.Default != nil {
+		def, err := CompileByte(cond.Default)
+		if err != nil {
+			return nil, errors.New("the default segment condition is invalid: " + err.Error())
+		}
+		v.def = def
+	}
+
+	return v, nil
+}
+
+// Validate matches the string based on the compiled SegmentCondition, it stops
+// at the first invalid segment.
+// If a segment doesn't match, it will return a *SegmentError.
+func (v *SegmentValidator) Validate(text string) error {
+	return v.validate(text, false)
+}
+
+// ValidateAll matches the string based on the compiled SegmentCondition like
+// Validate, but it validates every segment with ValidateAll, and returns the
+// invalid ones as SegmentErrors.
+func (v *SegmentValidator) ValidateAll(text string) error {
+	return v.validate(text, true)
+}
+
+func (v *SegmentValidator) validate(text string, all bool) error {
+	if text == "" {
+		return &ValidationError{Rule: RuleEmpty, Offset: -1}
+	}
+
+	count := strings.Count(text, string(v.separator)) + 1
+	if v.minSegments > 0 && count < v.minSegments {
+		return &ValidationError{Rule: RuleMinSegments, Offset: -1, Limit: v.minSegments, Count: count}
+	}
+	if v.maxSegments > 0 && count > v.maxSegments {
+		return &ValidationError{Rule: RuleMaxSegments, Offset: -1, Limit: v.maxSegments, Count: count}
+	}
+
+	var errs SegmentErrors
+	for i, start := 0, 0; i < count; i++ {
+		end := strings.IndexByte(text[start:], v.separator)
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start
+		}
+		if sv := v.validator(i); sv != nil {
+			segment := text[start:end]
+			var err error
+			if all {
+				err = sv.ValidateAll(segment)
+			} else {
+				err = sv.Validate(segment)
+			}
+			if err != nil {
+				e := &SegmentError{Index: i, Segment: segment, Offset: start, Err: err}
+				if !all {
+					return e
+				}
+				errs = append(errs, e)
+			}
+		}
+		start = end + 1
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validator returns the validator of the i-th segment, nil if it has none.
+func (v *SegmentValidator) validator(i int) *ByteValidator {
+	if i < len(v.segments) && v.segments[i] != nil {
+		return v.segments[i]
+	}
+
+	return v.def
+}
@@ -0,0 +1,101 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSegment(t *testing.T) {
+	cond := &strgo.SegmentCondition{
+		Separator:   ':',
+		MinSegments: 2,
+		MaxSegments: 3,
+		Segments: []*strgo.ByteCondition{
+			{MaxLength: 10, OnlyContains: strgo.LowerAlphabeticSet},
+			nil,
+			{OnlyContains: strgo.CharSetOf([]byte("devstagingprod")...)},
+		},
+		Default: &strgo.ByteCondition{
+			OnlyContains:          strgo.AlphanumericSet.Union(strgo.CharSetOf('-')),
+			MustNotContainsPrefix: strgo.CharSetOf('-'),
+		},
+	}
+	assert.Nil(t, strgo.Segment("team:project-1:prod", cond))
+	assert.Nil(t, strgo.Segment("team:project-1", cond))
+	assert.NotNil(t, strgo.Segment("team", cond))
+	assert.EqualError(t, strgo.Segment("team:project:prod:eu", cond), "the string segment count cannot be more than 3")
+	assert.EqualError(t, strgo.Segment("team", cond), "the string segment count cannot be less than 2")
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(strgo.Segment("a:b:c:d", cond), &verr))
+	assert.Equal(t, strgo.RuleMaxSegments, verr.Rule)
+	assert.Equal(t, 3, verr.Limit)
+	assert.Equal(t, 4, verr.Count)
+	assert.True(t, errors.Is(strgo.Segment("team", cond), strgo.ErrMinSegments))
+	assert.True(t, errors.Is(strgo.Segment("", cond), strgo.ErrEmpty))
+
+	err := strgo.Segment("team:-project:prod", cond)
+	var serr *strgo.SegmentError
+	assert.True(t, errors.As(err, &serr))
+	assert.Equal(t, 1, serr.Index)
+	assert.Equal(t, "-project", serr.Segment)
+	assert.Equal(t, 5, serr.Offset)
+	assert.True(t, errors.Is(err, strgo.ErrMustNotContainsPrefix))
+	assert.EqualError(t, err, `the segment at index 1 ("-project") is invalid: the string must not contain prefix: -`)
+
+	err = strgo.Segment("team::prod", cond)
+	assert.True(t, errors.As(err, &serr))
+	assert.Equal(t, 1, serr.Index)
+	assert.True(t, errors.Is(err, strgo.ErrEmpty))
+
+	err = strgo.Segment("Team:project:qa", cond)
+	assert.True(t, errors.As(err, &serr))
+	assert.Equal(t, 0, serr.Index)
+}
+
+func TestSegment_Semver(t *testing.T) {
+	number := &strgo.ByteCondition{MaxLength: 5, OnlyContains: strgo.NumericSet}
+	v, err := strgo.CompileSegment(&strgo.SegmentCondition{
+		Separator:   '.',
+		MinSegments: 3,
+		MaxSegments: 3,
+		Default:     number,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.Validate("1.20.3"))
+	assert.NotNil(t, v.Validate("1.2"))
+	assert.NotNil(t, v.Validate("1.2.x"))
+	number.OnlyContains = strgo.AlphabeticSet
+	assert.Nil(t, v.Validate("1.20.3"))
+}
+
+func TestSegmentValidator_ValidateAll(t *testing.T) {
+	v, err := strgo.CompileSegment(&strgo.SegmentCondition{
+		Separator: '.',
+		Default:   &strgo.ByteCondition{OnlyContains: strgo.NumericSet, MaxLength: 3},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.ValidateAll("1.2.3"))
+	err = v.ValidateAll("1.x.3.4y5z")
+	var errs strgo.SegmentErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, 1, errs[0].Index)
+	assert.Equal(t, 3, errs[1].Index)
+	var verrs strgo.ValidationErrors
+	assert.True(t, errors.As(errs[1], &verrs))
+	assert.Len(t, verrs, 3)
+}
+
+func TestCompileSegment(t *testing.T) {
+	_, err := strgo.CompileSegment(nil)
+	assert.EqualError(t, err, "the condition is nil")
+	_, err = strgo.CompileSegment(&strgo.SegmentCondition{})
+	assert.EqualError(t, err, "the separator must be an ascii char")
+	_, err = strgo.CompileSegment(&strgo.SegmentCondition{
+		Separator: ':',
+		Segments:  []*strgo.ByteCondition{nil, {ClassCounts: []strgo.ClassCount{{Set: strgo.NumericSet, Min: 3, Max: 1}}}},
+	})
+	assert.NotNil(t, err)
+}
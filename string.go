@@ -2,13 +2,15 @@ package strgo
 
 import (
 	"errors"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
 )
 
 type StringCondition struct {
 	MinLength                 int
 	MaxLength                 int
+	LengthUnit                LengthUnit
 	OnlyContainsPrefixWord    []string
 	OnlyContainsSuffixWord    []string
 	MustContainsWord          []string
@@ -17,89 +19,665 @@ type StringCondition struct {
 	MustNotContainsPrefixWord []string
 	MustNotContainsSuffixWord []string
 	MayContainsWordOnce       []string
+	// WordCount limits how many times a word appears, like "the word 'v'
+	// exactly twice" with {"v": {Min: 2, Max: 2}}. The occurrences are counted
+	// without overlapping.
+	WordCount map[string]Range
+	// CaseInsensitive matches every word list, the prefix and suffix ones
+	// too, regardless of the case, folded as set by FoldMode.
+	CaseInsensitive bool
+	FoldMode        FoldMode
+	// Normalizer maps the chars of the text and of the words to a canonical
+	// form before the words are matched, after the case folding. Use
+	// LookalikeNormalizer to match the leetspeak and look-alike spellings.
+	Normalizer *Normalizer
+	// WholeWord matches the words of MustContainsWord, MustContainsWordOnce,
+	// MustNotContainsWord and MayContainsWordOnce only as whole words, so "ass"
+	// doesn't match "classic". The words are separated by the WordDelimiters
+	// chars, or by the Unicode word boundaries if it's empty.
+	WholeWord      bool
+	WordDelimiters CharSet
 }
 
 // String matches the string based on the StringCondition.
 // If one doesn't match, it will return an error.
+// The condition is only read, so it can be shared between goroutines.
+//
+// String looks for each word in the text on every call, which costs as many
+// passes over the text as there are words. A condition that folds the text,
+// matches whole words or counts words is compiled on every call instead. Use
+// CompileString to validate many strings with the same condition, its
+// automaton matches every word in a single pass.
 func String(text string, cond *StringCondition) error {
+	if cond == nil {
+		return errors.New("the condition is nil")
+	}
+	if cond.CaseInsensitive || cond.Normalizer != nil || cond.WholeWord || len(cond.WordCount) > 0 {
+		v, err := CompileString(cond)
+		if err != nil {
+			return err
+		}
+
+		return v.Validate(text)
+	}
+
+	errs := collector{}
+	validateWords(text, cond, &errs)
+
+	return errs.err()
+}
+
+// validateWords validates the text like a StringValidator of the condition,
+// looking for each word with strings.Index instead of the automaton. The
+// condition must not fold the text, match whole words or count words.
+func validateWords(text string, cond *StringCondition, errs *collector) {
 	if text == "" {
-		return errors.New("the string is empty")
+		errs.add(&ValidationError{Rule: RuleEmpty, Offset: -1})
+		return
+	}
+	if checkLength(text, cond.MinLength, cond.MaxLength, cond.LengthUnit.orDefault(LengthBytes), errs) {
+		return
+	}
+	if checkAffixes(text, cond.OnlyContainsPrefixWord, cond.OnlyContainsSuffixWord, cond.MustNotContainsPrefixWord, cond.MustNotContainsSuffixWord, errs) {
+		return
 	}
 
-	textLen := len(text)
+	for _, w := range cond.MustContainsWord {
+		if w != "" && !strings.Contains(text, w) && errs.add(&ValidationError{Rule: RuleMustContainsWord, Word: w, Offset: -1, Limit: 1}) {
+			return
+		}
+	}
+	for _, w := range cond.MustContainsWordOnce {
+		if w == "" {
+			continue
+		}
+		count, _, second := scanWord(text, w)
+		if count == 0 && errs.add(&ValidationError{Rule: RuleMustContainsWordOnce, Word: w, Offset: -1, Limit: 1}) {
+			return
+		}
+		if count > 1 && errs.add(&ValidationError{Rule: RuleMustContainsWordOnce, Word: w, Offset: second, Length: len(w), Limit: 1, Count: count}) {
+			return
+		}
+	}
+	for _, w := range cond.MustNotContainsWord {
+		if w == "" {
+			continue
+		}
+		if count, first, _ := scanWord(text, w); count > 0 && errs.add(&ValidationError{Rule: RuleMustNotContainsWord, Word: w, Offset: first, Length: len(w), Count: count}) {
+			return
+		}
+	}
+	for _, w := range cond.MayContainsWordOnce {
+		if w == "" {
+			continue
+		}
+		if count, _, second := scanWord(text, w); count > 1 && errs.add(&ValidationError{Rule: RuleMayContainsWordOnce, Word: w, Offset: second, Length: len(w), Limit: 1, Count: count}) {
+			return
+		}
+	}
+}
 
-	if cond.MinLength > 0 && textLen < cond.MinLength {
-		return errors.New("the string length cannot be less than " + strconv.Itoa(cond.MinLength))
+// scanWord returns how many times the word is in the text, without
+// overlapping, and where its first and its second occurrences start.
+func scanWord(text, w string) (count, first, second int) {
+	first = strings.Index(text, w)
+	if first < 0 {
+		return 0, -1, -1
 	}
-	if cond.MaxLength > 0 && textLen > cond.MaxLength {
-		return errors.New("the string length cannot be more than " + strconv.Itoa(cond.MaxLength))
+	rest := first + len(w)
+	second = strings.Index(text[rest:], w)
+	if second < 0 {
+		return 1, first, -1
 	}
+	second += rest
 
-	if cond.OnlyContainsPrefixWord != nil {
-		for _, v := range cond.OnlyContainsPrefixWord {
-			if v != "" && text[:len(v)] == v {
-				cond.OnlyContainsPrefixWord = nil
-				break
+	return 2 + strings.Count(text[second+len(w):], w), first, second
+}
+
+// StringValidator is a compiled StringCondition. All the word lists are merged
+// into one multi-pattern automaton, so every contains, count and once rule is
+// answered in a single pass over the text.
+// A StringValidator is immutable and safe for concurrent use by multiple goroutines.
+type StringValidator struct {
+	minLength                 int
+	maxLength                 int
+	lengthUnit                LengthUnit
+	onlyContainsPrefixWord    []string
+	onlyContainsSuffixWord    []string
+	mustNotContainsPrefixWord []string
+	mustNotContainsSuffixWord []string
+	mustContainsWord          []int32
+	mustContainsWordOnce      []int32
+	mustNotContainsWord       []int32
+	mayContainsWordOnce       []int32
+	wordCounts                []wordCount
+	// overCount is the count over the Max of the WordCount rule of each word,
+	// zero for the words without one.
+	overCount       []int32
+	automaton       *automaton
+	scratch         sync.Pool
+	caseInsensitive bool
+	foldMode        FoldMode
+	normalizer      *Normalizer
+	wholeWord       bool
+	wordDelimiters  CharSet
+	// folds is true when the text is folded or normalized before the words
+	// are matched.
+	folds bool
+	// listed maps the folded words back to the words as they're listed in the
+	// condition, for the errors.
+	listed    map[string]string
+	maxPrefix int
+	ringMask  int
+}
+
+type stringScratch struct {
+	counts  []int32
+	ends    []int
+	first   []int
+	second  []int
+	touched []int32
+	// over and overEnd are where the occurrence over the Max of the WordCount
+	// rule starts in the text and ends in the scanned bytes.
+	over      []int
+	overEnd   []int
+	overCount []int32
+	// firstEnd and secondEnd are where the first and the second occurrences
+	// end in the scanned bytes.
+	firstEnd  []int
+	secondEnd []int
+	// head and the ring keep the first and the last folded bytes of a
+	// case-insensitive scan, with the text offset each one comes from.
+	head   []byte
+	ring   []byte
+	origin []int
+	spans  []int
+	folder *folder
+	// boundaries tells if a match is a whole word.
+	boundaries boundaries
+}
+
+// reset clears only the counters touched by the last scan, so big word lists
+// don't cost a full clear on every validation.
+func (s *stringScratch) reset() {
+	for _, id := range s.touched {
+		s.counts[id] = 0
+		s.ends[id] = 0
+		s.first[id] = 0
+		s.second[id] = 0
+	}
+	s.touched = s.touched[:0]
+	s.boundaries.found = false
+}
+
+// CompileString builds the automaton of the StringCondition word lists once and
+// returns a StringValidator. The condition is only read, later changes to it
+// don't affect the returned validator.
+func CompileString(cond *StringCondition) (*StringValidator, error) {
+	if cond == nil {
+		return nil, errors.New("the condition is nil")
+	}
+
+	v := &StringValidator{
+		minLength:       cond.MinLength,
+		maxLength:       cond.MaxLength,
+		lengthUnit:      cond.LengthUnit.orDefault(LengthBytes),
+		caseInsensitive: cond.CaseInsensitive,
+		foldMode:        cond.FoldMode,
+		normalizer:      cond.Normalizer,
+		wholeWord:       cond.WholeWord,
+		wordDelimiters:  cond.WordDelimiters,
+		folds:           cond.CaseInsensitive || cond.Normalizer != nil,
+	}
+	if v.folds {
+		v.listed = map[string]string{}
+	}
+	v.onlyContainsPrefixWord = v.foldWords(cond.OnlyContainsPrefixWord)
+	v.onlyContainsSuffixWord = v.foldWords(cond.OnlyContainsSuffixWord)
+	v.mustNotContainsPrefixWord = v.foldWords(cond.MustNotContainsPrefixWord)
+	v.mustNotContainsSuffixWord = v.foldWords(cond.MustNotContainsSuffixWord)
+
+	var (
+		words []string
+		ids   = map[string]int32{}
+	)
+	setWords := func(list []string) []int32 {
+		var r []int32
+		for _, w := range v.foldWords(list) {
+			if w == "" {
+				continue
 			}
+			id, ok := ids[w]
+			if !ok {
+				id = int32(len(words))
+				ids[w] = id
+				words = append(words, w)
+			}
+			r = append(r, id)
 		}
-		if cond.OnlyContainsPrefixWord != nil {
-			return errors.New("the string prefix doesn't match with the given prefix words")
+		return r
+	}
+	v.mustContainsWord = setWords(cond.MustContainsWord)
+	v.mustContainsWordOnce = setWords(cond.MustContainsWordOnce)
+	v.mustNotContainsWord = setWords(cond.MustNotContainsWord)
+	v.mayContainsWordOnce = setWords(cond.MayContainsWordOnce)
+
+	countWords := make([]string, 0, len(cond.WordCount))
+	for w := range cond.WordCount {
+		countWords = append(countWords, w)
+	}
+	sort.Strings(countWords)
+	counted := map[int32]bool{}
+	for _, w := range countWords {
+		rng := cond.WordCount[w]
+		if !rng.valid() {
+			return nil, errors.New("the count range of word: " + w + ", is invalid")
+		}
+		for _, id := range setWords([]string{w}) {
+			if counted[id] {
+				return nil, errors.New("the word: " + w + ", has more than one count range")
+			}
+			counted[id] = true
+			v.wordCounts = append(v.wordCounts, wordCount{id: id, Range: rng})
 		}
 	}
-	if cond.OnlyContainsSuffixWord != nil {
-		for _, v := range cond.OnlyContainsSuffixWord {
-			if v != "" && text[textLen-len(v):] == v {
-				cond.OnlyContainsSuffixWord = nil
-				break
+	v.automaton = newAutomaton(words)
+	if len(v.wordCounts) > 0 {
+		v.overCount = make([]int32, len(words))
+		for _, wc := range v.wordCounts {
+			if wc.Max > 0 {
+				v.overCount[wc.id] = int32(wc.Max + 1)
 			}
 		}
-		if cond.OnlyContainsSuffixWord != nil {
-			return errors.New("the string suffix doesn't match with the given suffix words")
+	}
+
+	// The ring must hold the longest word, to find where its match starts in
+	// the text, and the byte before it for the whole words. It must hold the
+	// longest suffix word too.
+	ringSize := 1
+	if v.folds {
+		v.maxPrefix = maxLen(v.onlyContainsPrefixWord, v.mustNotContainsPrefixWord)
+		n := maxLen(words)
+		if v.wholeWord {
+			n++
+		}
+		if m := maxLen(v.onlyContainsSuffixWord, v.mustNotContainsSuffixWord); m > n {
+			n = m
 		}
+		for ringSize < n {
+			ringSize <<= 1
+		}
+		v.ringMask = ringSize - 1
 	}
-	if cond.MustNotContainsPrefixWord != nil {
-		for _, v := range cond.MustNotContainsPrefixWord {
-			if v != "" && text[:len(v)] == v {
-				return errors.New("the string must not contain prefix word: " + v)
-			}
+	v.scratch.New = func() interface{} {
+		s := &stringScratch{
+			counts:    make([]int32, len(words)),
+			ends:      make([]int, len(words)),
+			first:     make([]int, len(words)),
+			second:    make([]int, len(words)),
+			firstEnd:  make([]int, len(words)),
+			secondEnd: make([]int, len(words)),
+			overCount: v.overCount,
 		}
+		if v.overCount != nil {
+			s.over = make([]int, len(words))
+			s.overEnd = make([]int, len(words))
+		}
+		s.boundaries.delimiters = v.wordDelimiters
+		if v.folds {
+			s.head = make([]byte, 0, v.maxPrefix)
+			s.ring = make([]byte, ringSize)
+			s.origin = make([]int, ringSize)
+			s.spans = make([]int, ringSize)
+			s.folder = v.newFolder()
+		}
+		return s
+	}
+
+	return v, nil
+}
+
+// Validate matches the string based on the compiled StringCondition.
+// If one doesn't match, it will return a *ValidationError.
+func (v *StringValidator) Validate(text string) error {
+	errs := collector{}
+	v.validate(text, &errs)
+
+	return errs.err()
+}
+
+// ValidateAll matches the string based on the compiled StringCondition like
+// Validate, but it doesn't stop at the first violated rule. Every violation is
+// returned as ValidationErrors, ordered by their offset.
+func (v *StringValidator) ValidateAll(text string) error {
+	errs := collector{all: true}
+	v.validate(text, &errs)
+
+	return errs.err()
+}
+
+func (v *StringValidator) validate(text string, errs *collector) {
+	if text == "" {
+		errs.add(&ValidationError{Rule: RuleEmpty, Offset: -1})
+		return
+	}
+
+	textLen := len(text)
+
+	if checkLength(text, v.minLength, v.maxLength, v.lengthUnit, errs) {
+		return
+	}
+
+	if v.folds {
+		v.validateFolded(text, errs)
+		return
+	}
+
+	if checkAffixes(text, v.onlyContainsPrefixWord, v.onlyContainsSuffixWord, v.mustNotContainsPrefixWord, v.mustNotContainsSuffixWord, errs) {
+		return
+	}
+
+	a := v.automaton
+	if len(a.words) == 0 {
+		return
 	}
-	if cond.MustNotContainsSuffixWord != nil {
-		for _, v := range cond.MustNotContainsSuffixWord {
-			if v != "" && text[textLen-len(v):] == v {
-				return errors.New("the string must not contain suffix word: " + v)
+
+	scratch := v.scratch.Get().(*stringScratch)
+	defer func() {
+		scratch.reset()
+		v.scratch.Put(scratch)
+	}()
+
+	// Occurrences are counted without overlapping, the same way strings.Count does.
+	state := int32(0)
+	for i := 0; i < textLen; i++ {
+		state = a.step(state, text[i])
+		for s := a.out[state]; s >= 0; s = a.link[s] {
+			id := a.word[s]
+			start := i + 1 - len(a.words[id])
+			if v.wholeWord && !scratch.boundaries.whole(text, start, i+1) {
+				continue
 			}
+			scratch.hit(id, start, i+1, start)
 		}
 	}
 
-	if cond.MustContainsWord != nil {
-		for _, v := range cond.MustContainsWord {
-			if v != "" && !strings.Contains(text, v) {
-				return errors.New("the string must contain word: " + v)
+	v.checkWords(text, scratch, errs)
+}
+
+// validateFolded validates the text of a case-insensitive condition. The text
+// is folded on the fly while it's fed to the automaton, the first and the last
+// folded bytes are kept to match the prefix and suffix words.
+func (v *StringValidator) validateFolded(text string, errs *collector) {
+	a := v.automaton
+	scratch := v.scratch.Get().(*stringScratch)
+	defer func() {
+		scratch.reset()
+		v.scratch.Put(scratch)
+	}()
+
+	n, state := 0, int32(0)
+	head, ring, origin, spans, mask := scratch.head[:0], scratch.ring, scratch.origin, scratch.spans, v.ringMask
+	scratch.folder.fold(text, func(b byte, at, end int) {
+		if len(head) < v.maxPrefix {
+			head = append(head, b)
+		}
+		ring[n&mask] = b
+		origin[n&mask] = at
+		spans[n&mask] = end
+		n++
+		state = a.step(state, b)
+		for s := a.out[state]; s >= 0; s = a.link[s] {
+			id := a.word[s]
+			start := n - len(a.words[id])
+			// A whole word must start and end with a char of the text, not
+			// in the middle of the bytes folded from one.
+			if v.wholeWord && (end < 0 || start > 0 && spans[(start-1)&mask] < 0 || !scratch.boundaries.whole(text, origin[start&mask], end)) {
+				continue
 			}
+			scratch.hit(id, start, n, origin[start&mask])
 		}
-	}
-	if cond.MustContainsWordOnce != nil {
-		for _, v := range cond.MustContainsWordOnce {
-			if v != "" && strings.Count(text, v) != 1 {
-				return errors.New("the string must contain word: " + v + ", and it must be appeared once in the string")
+	})
+
+	// suffix returns the text offset of the folded suffix w, or -1 if the
+	// folded text doesn't end with w.
+	suffix := func(w string) int {
+		if w == "" || len(w) > n {
+			return -1
+		}
+		for i := 0; i < len(w); i++ {
+			if ring[(n-len(w)+i)&mask] != w[i] {
+				return -1
 			}
 		}
+		return origin[(n-len(w))&mask]
 	}
-	if cond.MustNotContainsWord != nil {
-		for _, v := range cond.MustNotContainsWord {
-			if v != "" && strings.Contains(text, v) {
-				return errors.New("the string must not contain word: " + v)
+
+	if v.onlyContainsPrefixWord != nil && !hasAnyPrefix(string(head), v.onlyContainsPrefixWord) && errs.add(&ValidationError{Rule: RuleOnlyContainsPrefixWord, Offset: 0}) {
+		return
+	}
+	if v.onlyContainsSuffixWord != nil {
+		found := false
+		for _, w := range v.onlyContainsSuffixWord {
+			if suffix(w) >= 0 {
+				found = true
+				break
 			}
 		}
+		if !found && errs.add(&ValidationError{Rule: RuleOnlyContainsSuffixWord, Offset: -1}) {
+			return
+		}
+	}
+	for _, w := range v.mustNotContainsPrefixWord {
+		if w != "" && strings.HasPrefix(string(head), w) && errs.add(&ValidationError{Rule: RuleMustNotContainsPrefixWord, Word: v.listed[w], Offset: 0, Length: v.spanEnd(text, scratch, len(w))}) {
+			return
+		}
+	}
+	for _, w := range v.mustNotContainsSuffixWord {
+		if at := suffix(w); at >= 0 && errs.add(&ValidationError{Rule: RuleMustNotContainsSuffixWord, Word: v.listed[w], Offset: at, Length: len(text) - at}) {
+			return
+		}
+	}
+
+	v.checkWords(text, scratch, errs)
+}
+
+// checkWords reports the violations of the word rules, from the occurrences
+// counted by the scan.
+func (v *StringValidator) checkWords(text string, scratch *stringScratch, errs *collector) {
+	counts, first, second := scratch.counts, scratch.first, scratch.second
+	length := func(offset, end int) int {
+		return v.spanEnd(text, scratch, end) - offset
+	}
+
+	for _, id := range v.mustContainsWord {
+		if counts[id] < 1 && errs.add(&ValidationError{Rule: RuleMustContainsWord, Word: v.word(id), Offset: -1, Limit: 1}) {
+			return
+		}
+	}
+	for _, id := range v.mustContainsWordOnce {
+		if counts[id] == 0 && errs.add(&ValidationError{Rule: RuleMustContainsWordOnce, Word: v.word(id), Offset: -1, Limit: 1}) {
+			return
+		}
+		if counts[id] > 1 && errs.add(&ValidationError{Rule: RuleMustContainsWordOnce, Word: v.word(id), Offset: second[id], Length: length(second[id], scratch.secondEnd[id]), Limit: 1, Count: int(counts[id])}) {
+			return
+		}
+	}
+	for _, id := range v.mustNotContainsWord {
+		if counts[id] > 0 && errs.add(&ValidationError{Rule: RuleMustNotContainsWord, Word: v.word(id), Offset: first[id], Length: length(first[id], scratch.firstEnd[id]), Count: int(counts[id])}) {
+			return
+		}
+	}
+	for _, id := range v.mayContainsWordOnce {
+		if counts[id] > 1 && errs.add(&ValidationError{Rule: RuleMayContainsWordOnce, Word: v.word(id), Offset: second[id], Length: length(second[id], scratch.secondEnd[id]), Limit: 1, Count: int(counts[id])}) {
+			return
+		}
+	}
+	for _, wc := range v.wordCounts {
+		count := int(counts[wc.id])
+		if count < wc.Min && errs.add(&ValidationError{Rule: RuleWordCount, Word: v.word(wc.id), Offset: -1, Limit: wc.Min, Count: count}) {
+			return
+		}
+		if wc.Max > 0 && count > wc.Max && errs.add(&ValidationError{Rule: RuleWordCount, Word: v.word(wc.id), Offset: scratch.over[wc.id], Length: length(scratch.over[wc.id], scratch.overEnd[wc.id]), Limit: wc.Max, Count: count}) {
+			return
+		}
+	}
+}
+
+// hit records an occurrence of the word id from start to end in the scanned
+// bytes, offset is where it starts in the text. The ends are kept in the
+// scanned bytes, spanEnd finds them in the text for the errors. An occurrence that overlaps the
+// previous one isn't counted.
+func (s *stringScratch) hit(id int32, start, end, offset int) {
+	if start < s.ends[id] {
+		return
+	}
+	switch s.counts[id] {
+	case 0:
+		s.touched = append(s.touched, id)
+		s.first[id] = offset
+		s.firstEnd[id] = end
+	case 1:
+		s.second[id] = offset
+		s.secondEnd[id] = end
+	}
+	s.counts[id]++
+	s.ends[id] = end
+	if s.overCount != nil && s.counts[id] == s.overCount[id] {
+		s.over[id] = offset
+		s.overEnd[id] = end
+	}
+}
+
+// spanEnd returns where the n-th scanned byte ends in the text: the end of the
+// char it's folded from, or n itself if the text isn't folded. It scans the
+// text again, it's only used for the errors.
+func (v *StringValidator) spanEnd(text string, scratch *stringScratch, n int) int {
+	if !v.folds {
+		return n
+	}
+
+	end, i, char := -1, 0, -1
+	scratch.folder.fold(text, func(_ byte, at, last int) {
+		i++
+		switch {
+		case end >= 0 || i < n:
+		case i == n:
+			char, end = at, last
+		case at != char:
+			end = at
+		default:
+			end = last
+		}
+	})
+	if end < 0 {
+		return len(text)
+	}
+
+	return end
+}
+
+// word returns the word of the id as it's listed in the condition.
+func (v *StringValidator) word(id int32) string {
+	w := v.automaton.words[id]
+	if v.folds {
+		return v.listed[w]
+	}
+
+	return w
+}
+
+// newFolder returns a folder that folds and normalizes like the condition.
+func (v *StringValidator) newFolder() *folder {
+	if v.normalizer != nil {
+		v.normalizer.ready()
+	}
+
+	return &folder{caseInsensitive: v.caseInsensitive, mode: v.foldMode, normalizer: v.normalizer}
+}
+
+// foldWords returns a copy of the words, folded and normalized if the
+// condition does.
+func (v *StringValidator) foldWords(words []string) []string {
+	if !v.folds {
+		return copyWords(words)
+	}
+	if words == nil {
+		return nil
+	}
+
+	f := v.newFolder()
+	folded := make([]string, len(words))
+	for i, w := range words {
+		folded[i] = f.string(w)
+		if _, ok := v.listed[folded[i]]; !ok {
+			v.listed[folded[i]] = w
+		}
 	}
-	if cond.MayContainsWordOnce != nil {
-		for _, v := range cond.MayContainsWordOnce {
-			if v != "" && strings.Count(text, v) > 1 {
-				return errors.New("the word: " + v + ", must be appeared once in the string")
+
+	return folded
+}
+
+func maxLen(lists ...[]string) int {
+	n := 0
+	for _, list := range lists {
+		for _, w := range list {
+			if len(w) > n {
+				n = len(w)
 			}
 		}
 	}
 
-	return nil
+	return n
+}
+
+func copyWords(words []string) []string {
+	if words == nil {
+		return nil
+	}
+
+	return append(make([]string, 0, len(words)), words...)
+}
+
+// checkAffixes checks the prefix and the suffix words of a text that isn't
+// folded. It returns true if the validation must stop.
+func checkAffixes(text string, onlyPrefix, onlySuffix, notPrefix, notSuffix []string, errs *collector) bool {
+	if onlyPrefix != nil && !hasAnyPrefix(text, onlyPrefix) && errs.add(&ValidationError{Rule: RuleOnlyContainsPrefixWord, Offset: 0}) {
+		return true
+	}
+	if onlySuffix != nil && !hasAnySuffix(text, onlySuffix) && errs.add(&ValidationError{Rule: RuleOnlyContainsSuffixWord, Offset: -1}) {
+		return true
+	}
+	for _, w := range notPrefix {
+		if w != "" && strings.HasPrefix(text, w) && errs.add(&ValidationError{Rule: RuleMustNotContainsPrefixWord, Word: w, Offset: 0, Length: len(w)}) {
+			return true
+		}
+	}
+	for _, w := range notSuffix {
+		if w != "" && strings.HasSuffix(text, w) && errs.add(&ValidationError{Rule: RuleMustNotContainsSuffixWord, Word: w, Offset: len(text) - len(w), Length: len(w)}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAnyPrefix(text string, words []string) bool {
+	for _, w := range words {
+		if w != "" && strings.HasPrefix(text, w) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAnySuffix(text string, words []string) bool {
+	for _, w := range words {
+		if w != "" && strings.HasSuffix(text, w) {
+			return true
+		}
+	}
+
+	return false
 }
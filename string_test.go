@@ -1,8 +1,13 @@
 package strgo_test
 
 import (
+	"errors"
 	"github.com/dalikewara/strgo"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -130,3 +135,333 @@ func TestString_MayContainsWordOnce(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.EqualError(t, err, "the word: doe, must be appeared once in the string")
 }
+
+func TestCompileString(t *testing.T) {
+	v, err := strgo.CompileString(&strgo.StringCondition{
+		MustContainsWord:     []string{"he", "she"},
+		MustContainsWordOnce: []string{"hers"},
+		MustNotContainsWord:  []string{"his"},
+		MayContainsWordOnce:  []string{"aa"},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.Validate("ushers"))
+	assert.Nil(t, v.Validate("ushers aaa"))
+	assert.EqualError(t, v.Validate("hers"), "the string must contain word: she")
+	assert.EqualError(t, v.Validate("ushershers"), "the string must contain word: hers, and it must be appeared once in the string")
+	assert.EqualError(t, v.Validate("ushers his"), "the string must not contain word: his")
+	assert.EqualError(t, v.Validate("ushers aaaa"), "the word: aa, must be appeared once in the string")
+	_, err = strgo.CompileString(nil)
+	assert.EqualError(t, err, "the condition is nil")
+}
+
+func TestCompileString_Blocklist(t *testing.T) {
+	var words []string
+	for i := 0; i < 5000; i++ {
+		words = append(words, "word"+strconv.Itoa(i)+"x")
+	}
+	v, err := strgo.CompileString(&strgo.StringCondition{
+		MustNotContainsWord: words,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, v.Validate("this text has word12 but no listed word"))
+	assert.EqualError(t, v.Validate("this text has word4999x in it"), "the string must not contain word: word4999x")
+}
+
+func TestCompileString_MatchesString(t *testing.T) {
+	words := []string{"a", "ab", "ba", "aba", "bab", "bb"}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		text := make([]byte, 1+r.Intn(8))
+		for j := range text {
+			text[j] = "ab"[r.Intn(2)]
+		}
+		cond := func() *strgo.StringCondition {
+			return &strgo.StringCondition{
+				MustContainsWord:          []string{words[r.Intn(len(words))]},
+				MustContainsWordOnce:      []string{words[r.Intn(len(words))], words[r.Intn(len(words))]},
+				MustNotContainsWord:       []string{words[r.Intn(len(words))], words[r.Intn(len(words))]},
+				MayContainsWordOnce:       []string{words[r.Intn(len(words))]},
+				MustNotContainsPrefixWord: []string{words[r.Intn(len(words))] + "bbb"},
+				MustNotContainsSuffixWord: []string{"bbb" + words[r.Intn(len(words))]},
+			}
+		}()
+		v, err := strgo.CompileString(cond)
+		assert.Nil(t, err)
+		assert.Equal(t, strgo.String(string(text), cond), v.Validate(string(text)), string(text))
+	}
+	assert.EqualError(t, strgo.String("ab", nil), "the condition is nil")
+}
+
+func TestString_DoesNotMutateCondition(t *testing.T) {
+	cond := &strgo.StringCondition{
+		OnlyContainsPrefixWord: []string{"joh"},
+		OnlyContainsSuffixWord: []string{"doe"},
+	}
+	err := strgo.String("johndoe", cond)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"joh"}, cond.OnlyContainsPrefixWord)
+	assert.Equal(t, []string{"doe"}, cond.OnlyContainsSuffixWord)
+	err = strgo.String("janedoe", cond)
+	assert.EqualError(t, err, "the string prefix doesn't match with the given prefix words")
+	err = strgo.String("johnson", cond)
+	assert.EqualError(t, err, "the string suffix doesn't match with the given suffix words")
+}
+
+func TestString_SharedCondition(t *testing.T) {
+	cond := &strgo.StringCondition{
+		OnlyContainsPrefixWord: []string{"joh"},
+		OnlyContainsSuffixWord: []string{"doe"},
+		MustContainsWordOnce:   []string{"n"},
+		MustNotContainsWord:    []string{"xx"},
+	}
+	v, err := strgo.CompileString(cond)
+	assert.Nil(t, err)
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				assert.Nil(t, strgo.String("johndoe", cond))
+				assert.NotNil(t, strgo.String("janedoe", cond))
+				assert.Nil(t, v.Validate("johndoe"))
+				assert.NotNil(t, v.Validate("johnxxdoe"))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestString_CaseInsensitive(t *testing.T) {
+	cond := &strgo.StringCondition{
+		OnlyContainsPrefixWord: []string{"Mr.", "Ms."},
+		MustNotContainsWord:    []string{"BadWord"},
+		MustContainsWordOnce:   []string{"Id"},
+		CaseInsensitive:        true,
+	}
+	assert.Nil(t, strgo.String("mr. smith id", cond))
+	assert.Nil(t, strgo.String("MS. SMITH ID", cond))
+	assert.EqualError(t, strgo.String("dr. smith id", cond), "the string prefix doesn't match with the given prefix words")
+	assert.EqualError(t, strgo.String("mr. smith id ID", cond), "the string must contain word: Id, and it must be appeared once in the string")
+
+	err := strgo.String("Mr. bAdWoRd id", cond)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "BadWord", verr.Word)
+	assert.Equal(t, 4, verr.Offset)
+	assert.EqualError(t, err, "the string must not contain word: BadWord")
+
+	cond.CaseInsensitive = false
+	assert.EqualError(t, strgo.String("mr. smith id", cond), "the string prefix doesn't match with the given prefix words")
+}
+
+func TestString_FoldMode(t *testing.T) {
+	tests := []struct {
+		mode  strgo.FoldMode
+		text  string
+		words []string
+		valid bool
+	}{
+		{strgo.FoldASCII, "BADWORD", []string{"badword"}, false},
+		{strgo.FoldASCII, "ÉTÉ", []string{"été"}, true},
+		{strgo.FoldUnicode, "ÉTÉ", []string{"été"}, false},
+		{strgo.FoldUnicode, "STRASSE", []string{"straße"}, false},
+		{strgo.FoldUnicode, "ｂａｄ", []string{"bad"}, true},
+		{strgo.FoldNFKC, "ｂａｄ", []string{"bad"}, false},
+		{strgo.FoldNFKC, "ＢＡＤ", []string{"bad"}, false},
+		{strgo.FoldNFKC, "ﬁle", []string{"FILE"}, false},
+		{strgo.FoldNFKC, "good", []string{"bad"}, true},
+	}
+	for _, tt := range tests {
+		err := strgo.String(tt.text, &strgo.StringCondition{
+			MustNotContainsWord: tt.words,
+			CaseInsensitive:     true,
+			FoldMode:            tt.mode,
+		})
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+}
+
+func TestString_FoldMode_Offset(t *testing.T) {
+	v, err := strgo.CompileString(&strgo.StringCondition{
+		MustNotContainsWord:       []string{"bad"},
+		MustNotContainsSuffixWord: []string{"SS"},
+		CaseInsensitive:           true,
+		FoldMode:                  strgo.FoldNFKC,
+	})
+	assert.Nil(t, err)
+
+	err = v.ValidateAll("so ＢＡＤ Fuß")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, strgo.RuleMustNotContainsWord, errs[0].Rule)
+	assert.Equal(t, 3, errs[0].Offset)
+	assert.Equal(t, strgo.RuleMustNotContainsSuffixWord, errs[1].Rule)
+	assert.Equal(t, "SS", errs[1].Word)
+	assert.Equal(t, len("so ＢＡＤ Fu"), errs[1].Offset)
+}
+
+func TestString_CaseInsensitive_MatchesLower(t *testing.T) {
+	words := []string{"a", "aB", "Ba", "ABA", "bab", "bb"}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		text := make([]byte, 1+r.Intn(8))
+		for j := range text {
+			text[j] = "abAB "[r.Intn(5)]
+		}
+		pick := func() []string {
+			return []string{words[r.Intn(len(words))]}
+		}
+		cond := &strgo.StringCondition{
+			OnlyContainsSuffixWord: pick(),
+			MustContainsWord:       pick(),
+			MustContainsWordOnce:   pick(),
+			MustNotContainsWord:    pick(),
+			MayContainsWordOnce:    pick(),
+			CaseInsensitive:        true,
+			WholeWord:              r.Intn(2) == 0,
+		}
+		if r.Intn(2) == 0 {
+			cond.WordDelimiters = strgo.CharSetOf(' ')
+		}
+		lower := func(list []string) []string {
+			return []string{strings.ToLower(list[0])}
+		}
+		want := strgo.String(strings.ToLower(string(text)), &strgo.StringCondition{
+			OnlyContainsSuffixWord: lower(cond.OnlyContainsSuffixWord),
+			MustContainsWord:       lower(cond.MustContainsWord),
+			MustContainsWordOnce:   lower(cond.MustContainsWordOnce),
+			MustNotContainsWord:    lower(cond.MustNotContainsWord),
+			MayContainsWordOnce:    lower(cond.MayContainsWordOnce),
+			WholeWord:              cond.WholeWord,
+			WordDelimiters:         cond.WordDelimiters,
+		})
+		got := strgo.String(string(text), cond)
+		assert.Equal(t, want == nil, got == nil, string(text))
+		var werr, gerr *strgo.ValidationError
+		if errors.As(want, &werr) && errors.As(got, &gerr) {
+			assert.Equal(t, werr.Rule, gerr.Rule, string(text))
+			assert.Equal(t, werr.Offset, gerr.Offset, string(text))
+			assert.Equal(t, strings.ToLower(gerr.Word), werr.Word, string(text))
+		}
+	}
+}
+
+func TestString_WholeWord(t *testing.T) {
+	tests := []struct {
+		text  string
+		valid bool
+	}{
+		{"classic", true},
+		{"password", true},
+		{"ass123", true},
+		{"ass", false},
+		{"you ass!", false},
+		{"kick-ass", false},
+		{"\"ass\"", false},
+	}
+	for _, tt := range tests {
+		err := strgo.String(tt.text, &strgo.StringCondition{
+			MustNotContainsWord: []string{"ass"},
+			WholeWord:           true,
+		})
+		assert.Equal(t, tt.valid, err == nil, tt.text)
+	}
+
+	cond := &strgo.StringCondition{
+		MustContainsWord:     []string{"gopher"},
+		MustContainsWordOnce: []string{"go"},
+		MayContainsWordOnce:  []string{"is"},
+		WholeWord:            true,
+	}
+	assert.Nil(t, strgo.String("this gopher is gone, go", cond))
+	assert.EqualError(t, strgo.String("gophers go", cond), "the string must contain word: gopher")
+
+	err := strgo.String("go gopher go", cond)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, strgo.RuleMustContainsWordOnce, verr.Rule)
+	assert.Equal(t, 10, verr.Offset)
+	assert.Equal(t, 2, verr.Count)
+}
+
+func TestString_WordDelimiters(t *testing.T) {
+	cond := &strgo.StringCondition{
+		MustNotContainsWord: []string{"ass"},
+		WholeWord:           true,
+		WordDelimiters:      strgo.CharSetOf(' ', ','),
+	}
+	assert.Nil(t, strgo.String("kick-ass", cond))
+	assert.Nil(t, strgo.String("ass!", cond))
+	assert.NotNil(t, strgo.String("ok,ass", cond))
+	assert.NotNil(t, strgo.String("ass, ok", cond))
+
+	v, err := strgo.CompileString(cond)
+	assert.Nil(t, err)
+	cond.WordDelimiters = strgo.CharSetOf('-')
+	assert.Nil(t, v.Validate("x-ass-y"))
+}
+
+func TestString_WholeWord_CaseInsensitive(t *testing.T) {
+	cond := &strgo.StringCondition{
+		MustNotContainsWord: []string{"straße", "s", "i"},
+		CaseInsensitive:     true,
+		FoldMode:            strgo.FoldNFKC,
+		WholeWord:           true,
+	}
+	assert.Nil(t, strgo.String("Maß", cond))
+	assert.Nil(t, strgo.String("ﬁ", cond))
+	assert.Nil(t, strgo.String("STRASSEN", cond))
+
+	err := strgo.String("the STRASSE!", cond)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "straße", verr.Word)
+	assert.Equal(t, 4, verr.Offset)
+
+	cond.WholeWord = false
+	err = strgo.String("a ﬁ", cond)
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "i", verr.Word)
+	assert.Equal(t, 2, verr.Offset)
+}
+
+func TestString_WordCount(t *testing.T) {
+	cond := &strgo.StringCondition{
+		WordCount: map[string]strgo.Range{
+			"v":    {Min: 2, Max: 2},
+			"beta": {Max: 1},
+		},
+	}
+	assert.Nil(t, strgo.String("v1-v2", cond))
+	assert.EqualError(t, strgo.String("v1", cond), "the word: v, must be appeared at least 2 time(s) in the string")
+	assert.EqualError(t, strgo.String("v1-v2-v3", cond), "the word: v, must be appeared at most 2 time(s) in the string")
+
+	v, err := strgo.CompileString(cond)
+	assert.Nil(t, err)
+	err = v.ValidateAll("beta v1 beta v2 beta")
+	var errs strgo.ValidationErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, strgo.RuleWordCount, errs[0].Rule)
+	assert.Equal(t, "beta", errs[0].Word)
+	assert.Equal(t, 8, errs[0].Offset)
+	assert.Equal(t, 1, errs[0].Limit)
+	assert.Equal(t, 3, errs[0].Count)
+	assert.True(t, errors.Is(err, strgo.ErrWordCount))
+
+	err = strgo.String("V1 BETA v2 Beta", &strgo.StringCondition{
+		WordCount:       map[string]strgo.Range{"Beta": {Max: 1}, "v": {Min: 2}},
+		CaseInsensitive: true,
+	})
+	assert.EqualError(t, err, "the word: Beta, must be appeared at most 1 time(s) in the string")
+
+	_, err = strgo.CompileString(&strgo.StringCondition{WordCount: map[string]strgo.Range{"v": {Min: -1}}})
+	assert.EqualError(t, err, "the count range of word: v, is invalid")
+	_, err = strgo.CompileString(&strgo.StringCondition{
+		WordCount:       map[string]strgo.Range{"V": {Max: 1}, "v": {Max: 2}},
+		CaseInsensitive: true,
+	})
+	assert.EqualError(t, err, "the word: v, has more than one count range")
+}
@@ -0,0 +1,521 @@
+package strgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FieldError is a violation found by ValidateStruct, keyed by the path of the
+// field, like "Users[2].Email" or "Labels[env]".
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors is the list of violations returned by ValidateStruct, one per
+// invalid field, in the order the fields were walked.
+type FieldErrors []*FieldError
+
+// Error returns the messages of all violations, one per line.
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the violations, so errors.Is and errors.As look into each of them.
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, v := range e {
+		errs[i] = v
+	}
+
+	return errs
+}
+
+// ValidateStruct validates the string fields of a struct that have a strgo tag.
+// It walks nested structs, pointers, slices, arrays and maps, and returns the
+// violations as FieldErrors. A tag on a slice or map of strings applies to
+// each of its strings, the entries of a map are walked in the order of their
+// keys.
+//
+// A tag is a comma-separated list of options:
+//
+//	preset=username   start from a preset condition: username (UsernameCondition(3, 20)),
+//	                  email (EmailCondition) or password (DefaultPasswordPolicy)
+//	min=3, max=20     MinLength and MaxLength
+//	unit=runes        LengthUnit: bytes, runes, graphemes or width
+//	only=SET          OnlyContains
+//	prefix=SET        OnlyContainsPrefix
+//	suffix=SET        OnlyContainsSuffix
+//	must=SET          MustContains
+//	mustonce=SET      MustContainsOnce
+//	not=SET           MustNotContains
+//	noprefix=SET      MustNotContainsPrefix
+//	nosuffix=SET      MustNotContainsSuffix
+//	once=SET          MayContainsOnce
+//	follow=SET        MustBeFollowedBy chars, with followby=SET as their neighbours,
+//	                  and the same for precede, surround, notfollow and notprecede
+//	upper=N, lower=N, number=N, special=N
+//	                  the AtLeastHave*Count rules
+//	repeat=N          MaxRepeatRun
+//	sequence=N        MaxSequentialRun
+//	classrun=N        MaxSameClassRun
+//	omitempty         skip the field if it's empty
+//
+// A SET is a list of tokens separated by "|". A token is one of the classes
+// alpha, lower, upper, numeric (or digit), alnum, special, quotes, brackets, operators,
+// chars, or one of the names comma, pipe, space, equal for these chars.
+// Any other token is taken as literal chars, so "alnum|_." is the
+// alphanumeric chars plus '_' and '.'.
+//
+//	type User struct {
+//		Username string `strgo:"preset=username,min=3,max=20"`
+//		Nickname string `strgo:"only=alnum|_.,once=_.,omitempty"`
+//	}
+//
+// The tags are parsed and compiled once per struct type. An invalid tag is
+// returned as an error that isn't a FieldErrors.
+func ValidateStruct(v interface{}) error {
+	w := structWalker{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("the value is nil")
+		}
+		if rv.Elem().Kind() == reflect.Struct {
+			w.visited = map[uintptr]bool{rv.Pointer(): true}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errors.New("the value is not a struct: " + rv.Kind().String())
+	}
+
+	if err := w.walkStruct(rv, ""); err != nil {
+		return err
+	}
+	if len(w.errs) > 0 {
+		return w.errs
+	}
+
+	return nil
+}
+
+// structPlan is the compiled form of a struct type: its fields to validate or
+// to walk into.
+type structPlan struct {
+	fields []fieldPlan
+}
+
+type fieldPlan struct {
+	index     int
+	name      string
+	validator *ByteValidator
+	omitEmpty bool
+}
+
+var structPlans sync.Map
+
+func planOf(t reflect.Type) (*structPlan, error) {
+	if p, ok := structPlans.Load(t); ok {
+		return p.(*structPlan), nil
+	}
+
+	p := &structPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag, hasTag := f.Tag.Lookup("strgo")
+		if tag == "-" {
+			continue
+		}
+		fp := fieldPlan{index: i, name: f.Name}
+		if hasTag {
+			if !mayHoldString(f.Type) {
+				return nil, errors.New("the field: " + t.Name() + "." + f.Name + ", has a tag but cannot hold a string: " + f.Type.String())
+			}
+			cond, omitEmpty, err := parseTag(tag)
+			if err != nil {
+				return nil, errors.New("the tag of the field: " + t.Name() + "." + f.Name + ", is invalid: " + err.Error())
+			}
+			if fp.validator, err = CompileByte(cond); err != nil {
+				return nil, errors.New("the tag of the field: " + t.Name() + "." + f.Name + ", is invalid: " + err.Error())
+			}
+			fp.omitEmpty = omitEmpty
+		} else if !mayHoldStrings(f.Type) {
+			continue
+		}
+		p.fields = append(p.fields, fp)
+	}
+
+	actual, _ := structPlans.LoadOrStore(t, p)
+
+	return actual.(*structPlan), nil
+}
+
+// mayHoldStrings tells if a value of the type may hold a tagged field somewhere.
+func mayHoldStrings(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return mayHoldStrings(t.Elem())
+	case reflect.Struct, reflect.Interface:
+		return true
+	}
+
+	return false
+}
+
+// mayHoldString tells if a tagged field of the type may hold a string to
+// validate.
+func mayHoldString(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return mayHoldString(t.Elem())
+	case reflect.String, reflect.Interface:
+		return true
+	}
+
+	return false
+}
+
+type structWalker struct {
+	errs FieldErrors
+	// visited holds the struct pointers of the current path, so a cycle is
+	// walked once, but a pointer shared by two fields is walked from both.
+	visited map[uintptr]bool
+}
+
+func (w *structWalker) walkStruct(rv reflect.Value, path string) error {
+	p, err := planOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range p.fields {
+		fieldPath := f.name
+		if path != "" {
+			fieldPath = path + "." + f.name
+		}
+		if err := w.walk(rv.Field(f.index), fieldPath, &f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walk validates the strings of rv with the validator of the field, and
+// walks into its structs.
+func (w *structWalker) walk(rv reflect.Value, path string, f *fieldPlan) error {
+	switch rv.Kind() {
+	case reflect.String:
+		if f.validator == nil {
+			return nil
+		}
+		text := rv.String()
+		if text == "" && f.omitEmpty {
+			return nil
+		}
+		if err := f.validator.Validate(text); err != nil {
+			w.errs = append(w.errs, &FieldError{Path: path, Err: err})
+		}
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Elem().Kind() == reflect.Struct {
+			ptr := rv.Pointer()
+			if w.visited[ptr] {
+				return nil
+			}
+			if w.visited == nil {
+				w.visited = map[uintptr]bool{}
+			}
+			w.visited[ptr] = true
+			defer delete(w.visited, ptr)
+		}
+		return w.walk(rv.Elem(), path, f)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return w.walk(rv.Elem(), path, f)
+	case reflect.Struct:
+		return w.walkStruct(rv, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := w.walk(rv.Index(i), path+"["+strconv.Itoa(i)+"]", f); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys, names := sortedKeys(rv)
+		for i, key := range keys {
+			if err := w.walk(rv.MapIndex(key), path+"["+names[i]+"]", f); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of the map and their names in the paths, sorted
+// so the violations come in the same order on every call. The numbers are
+// sorted by value, the other keys by name.
+func sortedKeys(rv reflect.Value) ([]reflect.Value, []string) {
+	keys := rv.MapKeys()
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = fmt.Sprint(key.Interface())
+	}
+	sort.Sort(mapKeys{keys: keys, names: names})
+
+	return keys, names
+}
+
+type mapKeys struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (k mapKeys) Len() int {
+	return len(k.keys)
+}
+
+func (k mapKeys) Less(i, j int) bool {
+	a, b := k.keys[i], k.keys[j]
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		}
+	}
+
+	return k.names[i] < k.names[j]
+}
+
+func (k mapKeys) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.names[i], k.names[j] = k.names[j], k.names[i]
+}
+
+var tagPresets = map[string]func() *ByteCondition{
+	"username": func() *ByteCondition { return UsernameCondition(3, 20) },
+	"email":    EmailCondition,
+	"password": func() *ByteCondition { return PasswordCondition(DefaultPasswordPolicy) },
+}
+
+var tagClasses = map[string]CharSet{
+	"alpha":     AlphabeticSet,
+	"lower":     LowerAlphabeticSet,
+	"upper":     UpperAlphabeticSet,
+	"numeric":   NumericSet,
+	"digit":     NumericSet,
+	"alnum":     AlphanumericSet,
+	"special":   SpecialCharsSet,
+	"quotes":    QuotesSet,
+	"brackets":  BracketsSet,
+	"operators": OperatorsSet,
+	"chars":     CharsSet,
+	"comma":     CharSetOf(','),
+	"pipe":      CharSetOf('|'),
+	"space":     CharSetOf(' '),
+	"equal":     CharSetOf('='),
+}
+
+// tagPairs are the options of the adjacency rules, each is set with its "by"
+// option that holds the neighbours, like follow=SET,followby=SET.
+var tagPairs = [...]struct {
+	key   string
+	field func(cond *ByteCondition) *[][2]CharSet
+}{
+	{"follow", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustBeFollowedBy }},
+	{"precede", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustBePrecededBy }},
+	{"surround", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustBeSurroundedBy }},
+	{"notfollow", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustNotBeFollowedBy }},
+	{"notprecede", func(cond *ByteCondition) *[][2]CharSet { return &cond.MustNotBePrecededBy }},
+}
+
+var tagUnits = map[string]LengthUnit{
+	"bytes":     LengthBytes,
+	"runes":     LengthRunes,
+	"graphemes": LengthGraphemes,
+	"width":     LengthDisplayWidth,
+}
+
+// parseTag parses a strgo struct tag into a ByteCondition. The preset is
+// applied first, so the other options override it wherever they're written.
+func parseTag(tag string) (cond *ByteCondition, omitEmpty bool, err error) {
+	var opts [][2]string
+	var pairs [len(tagPairs)][2]CharSet
+	cond = &ByteCondition{}
+
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		if opt == "omitempty" {
+			omitEmpty = true
+			continue
+		}
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok || value == "" {
+			return nil, false, errors.New("the option: " + opt + ", must be written as key=value")
+		}
+		if key == "preset" {
+			preset, ok := tagPresets[value]
+			if !ok {
+				return nil, false, errors.New("unknown preset: " + value)
+			}
+			cond = preset()
+			continue
+		}
+		opts = append(opts, [2]string{key, value})
+	}
+
+	for _, opt := range opts {
+		key, value := opt[0], opt[1]
+		switch key {
+		case "min", "max", "upper", "lower", "number", "special", "repeat", "sequence", "classrun":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, false, errors.New("the option: " + key + ", must be a positive number")
+			}
+			*tagInt(cond, key) = n
+		case "unit":
+			unit, ok := tagUnits[value]
+			if !ok {
+				return nil, false, errors.New("unknown unit: " + value)
+			}
+			cond.LengthUnit = unit
+		default:
+			set, err := parseTagSet(value)
+			if err != nil {
+				return nil, false, err
+			}
+			if k, side := tagPair(key); k >= 0 {
+				pairs[k][side] = set
+				continue
+			}
+			field := tagSet(cond, key)
+			if field == nil {
+				return nil, false, errors.New("unknown option: " + key)
+			}
+			*field = set
+		}
+	}
+	for k, pair := range pairs {
+		if pair[0].IsEmpty() != pair[1].IsEmpty() {
+			return nil, false, errors.New("the options " + tagPairs[k].key + " and " + tagPairs[k].key + "by must be set together")
+		}
+		if !pair[0].IsEmpty() {
+			*tagPairs[k].field(cond) = [][2]CharSet{pair}
+		}
+	}
+
+	return cond, omitEmpty, nil
+}
+
+func parseTagSet(value string) (CharSet, error) {
+	var set CharSet
+	for _, token := range strings.Split(value, "|") {
+		if class, ok := tagClasses[token]; ok {
+			set = set.Union(class)
+			continue
+		}
+		if err := checkASCII([]byte(token)); err != nil {
+			return CharSet{}, err
+		}
+		set = set.Union(CharSetOf([]byte(token)...))
+	}
+
+	return set, nil
+}
+
+func tagInt(cond *ByteCondition, key string) *int {
+	switch key {
+	case "min":
+		return &cond.MinLength
+	case "max":
+		return &cond.MaxLength
+	case "upper":
+		return &cond.AtLeastHaveUpperLetterCount
+	case "lower":
+		return &cond.AtLeastHaveLowerLetterCount
+	case "number":
+		return &cond.AtLeastHaveNumberCount
+	case "repeat":
+		return &cond.MaxRepeatRun
+	case "sequence":
+		return &cond.MaxSequentialRun
+	case "classrun":
+		return &cond.MaxSameClassRun
+	}
+
+	return &cond.AtLeastHaveSpecialCharCount
+}
+
+func tagSet(cond *ByteCondition, key string) *CharSet {
+	switch key {
+	case "only":
+		return &cond.OnlyContains
+	case "prefix":
+		return &cond.OnlyContainsPrefix
+	case "suffix":
+		return &cond.OnlyContainsSuffix
+	case "must":
+		return &cond.MustContains
+	case "mustonce":
+		return &cond.MustContainsOnce
+	case "not":
+		return &cond.MustNotContains
+	case "noprefix":
+		return &cond.MustNotContainsPrefix
+	case "nosuffix":
+		return &cond.MustNotContainsSuffix
+	case "once":
+		return &cond.MayContainsOnce
+	}
+
+	return nil
+}
+
+// tagPair returns the index in tagPairs of the adjacency option key, and 0 for
+// its chars or 1 for its neighbours. The index is -1 if key isn't one.
+func tagPair(key string) (int, int) {
+	for k, pair := range tagPairs {
+		switch key {
+		case pair.key:
+			return k, 0
+		case pair.key + "by":
+			return k, 1
+		}
+	}
+
+	return -1, 0
+}
@@ -0,0 +1,186 @@
+package strgo_test
+
+import (
+	"errors"
+	"github.com/dalikewara/strgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type structUser struct {
+	Username string   `strgo:"preset=username,min=3,max=20"`
+	Email    string   `strgo:"preset=email"`
+	Nickname string   `strgo:"only=alnum|_.,once=_.,omitempty"`
+	Tags     []string `strgo:"only=lower|-"`
+	Note     string
+	secret   string
+}
+
+type structTeam struct {
+	Name   string `strgo:"min=2,max=10,unit=runes"`
+	Users  []structUser
+	Owner  *structUser
+	Labels map[string]string `strgo:"only=alnum"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	user := structUser{Username: "john_doe", Email: "john@email.com", Tags: []string{"go", "open-source"}, secret: "!"}
+	assert.Nil(t, strgo.ValidateStruct(user))
+	assert.Nil(t, strgo.ValidateStruct(&user))
+	user.Nickname = "john..doe"
+	user.Tags = append(user.Tags, "Go")
+	err := strgo.ValidateStruct(&user)
+	var errs strgo.FieldErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "Nickname", errs[0].Path)
+	assert.Equal(t, "Tags[2]", errs[1].Path)
+	assert.True(t, errors.Is(err, strgo.ErrMayContainsOnce))
+	assert.True(t, errors.Is(err, strgo.ErrOnlyContains))
+	assert.EqualError(t, err, "Nickname: the char: ., must be appeared once in the string\nTags[2]: the string cannot contain char: G")
+}
+
+func TestValidateStruct_Nested(t *testing.T) {
+	team := structTeam{
+		Name: "gophers",
+		Users: []structUser{
+			{Username: "john", Email: "john@email.com"},
+			{Username: "jo", Email: "jo@email.com"},
+			{Username: "jane", Email: "jane@email@com"},
+		},
+		Owner:  &structUser{Username: "john", Email: "john.email.com"},
+		Labels: map[string]string{"env": "prod-1"},
+	}
+	err := strgo.ValidateStruct(&team)
+	var errs strgo.FieldErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 4)
+	assert.Equal(t, "Users[1].Username", errs[0].Path)
+	assert.True(t, errors.Is(errs[0], strgo.ErrMinLength))
+	assert.Equal(t, "Users[2].Email", errs[1].Path)
+	assert.True(t, errors.Is(errs[1], strgo.ErrMustContainsOnce))
+	assert.Equal(t, "Owner.Email", errs[2].Path)
+	assert.Equal(t, "Labels[env]", errs[3].Path)
+	var verr *strgo.ValidationError
+	assert.True(t, errors.As(errs[3], &verr))
+	assert.Equal(t, '-', verr.Char)
+}
+
+func TestValidateStruct_Preset(t *testing.T) {
+	type form struct {
+		Password string `strgo:"max=8,preset=password"`
+		PIN      string `strgo:"only=digit,min=4,max=6,repeat=2,sequence=3"`
+	}
+	assert.Nil(t, strgo.ValidateStruct(form{Password: "Pass.123", PIN: "9215"}))
+	assert.True(t, errors.Is(strgo.ValidateStruct(form{Password: "Pass.1234", PIN: "9215"}), strgo.ErrMaxLength))
+	assert.True(t, errors.Is(strgo.ValidateStruct(form{Password: "pass.123", PIN: "9215"}), strgo.ErrAtLeastHaveUpperLetterCount))
+	assert.True(t, errors.Is(strgo.ValidateStruct(form{Password: "Pass.123", PIN: "1234"}), strgo.ErrMaxSequentialRun))
+	assert.True(t, errors.Is(strgo.ValidateStruct(form{Password: "Pass.123", PIN: "9111"}), strgo.ErrMaxRepeatRun))
+}
+
+func TestValidateStruct_Adjacency(t *testing.T) {
+	type form struct {
+		Version string `strgo:"only=numeric|.-,surround=.,surroundby=numeric,notfollow=-,notfollowby=-"`
+		Handle  string `strgo:"preset=username,precede=_,precedeby=lower"`
+	}
+	assert.Nil(t, strgo.ValidateStruct(form{Version: "1.2-3", Handle: "john_doe"}))
+	assert.True(t, errors.Is(strgo.ValidateStruct(form{Version: "1..2", Handle: "john_doe"}), strgo.ErrMustBeSurroundedBy))
+	assert.True(t, errors.Is(strgo.ValidateStruct(form{Version: "1.2--3", Handle: "john_doe"}), strgo.ErrMustNotBeFollowedBy))
+	assert.True(t, errors.Is(strgo.ValidateStruct(form{Version: "1.2", Handle: "JOHN_doe"}), strgo.ErrMustBePrecededBy))
+}
+
+func TestValidateStruct_InvalidTag(t *testing.T) {
+	type badOption struct {
+		Name string `strgo:"size=3"`
+	}
+	type badSet struct {
+		Name string `strgo:"only=é"`
+	}
+	type badFollow struct {
+		Name string `strgo:"follow=_"`
+	}
+	err := strgo.ValidateStruct(badOption{Name: "john"})
+	assert.EqualError(t, err, "the tag of the field: badOption.Name, is invalid: unknown option: size")
+	var errs strgo.FieldErrors
+	assert.False(t, errors.As(err, &errs))
+	assert.NotNil(t, strgo.ValidateStruct(badSet{Name: "john"}))
+	assert.NotNil(t, strgo.ValidateStruct(badFollow{Name: "john"}))
+	type badKind struct {
+		N int `strgo:"min=3"`
+	}
+	assert.EqualError(t, strgo.ValidateStruct(badKind{N: 1}), "the field: badKind.N, has a tag but cannot hold a string: int")
+	assert.EqualError(t, strgo.ValidateStruct("john"), "the value is not a struct: string")
+	assert.EqualError(t, strgo.ValidateStruct((*structUser)(nil)), "the value is nil")
+}
+
+func TestValidateStruct_Cycle(t *testing.T) {
+	type node struct {
+		Name string `strgo:"only=lower"`
+		Next *node
+	}
+	a := &node{Name: "a"}
+	b := &node{Name: "B", Next: a}
+	a.Next = b
+	err := strgo.ValidateStruct(a)
+	var errs strgo.FieldErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Next.Name", errs[0].Path)
+}
+
+func TestValidateStruct_SelfReference(t *testing.T) {
+	type node struct {
+		Name string `strgo:"only=lower"`
+		Self *node
+	}
+	u := node{Name: "A"}
+	u.Self = &u
+	err := strgo.ValidateStruct(&u)
+	var errs strgo.FieldErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Name", errs[0].Path)
+}
+
+func TestValidateStruct_MapOrder(t *testing.T) {
+	type form struct {
+		Codes  map[int]string    `strgo:"only=numeric"`
+		Labels map[string]string `strgo:"only=lower"`
+	}
+	f := form{
+		Codes:  map[int]string{10: "a", 9: "b", -1: "c", 2: "1"},
+		Labels: map[string]string{"zone": "A", "env": "B", "app": "c"},
+	}
+	for i := 0; i < 10; i++ {
+		var errs strgo.FieldErrors
+		assert.True(t, errors.As(strgo.ValidateStruct(f), &errs))
+		paths := make([]string, len(errs))
+		for k, e := range errs {
+			paths[k] = e.Path
+		}
+		assert.Equal(t, []string{"Codes[-1]", "Codes[9]", "Codes[10]", "Labels[env]", "Labels[zone]"}, paths)
+	}
+}
+
+func TestValidateStruct_SharedPointer(t *testing.T) {
+	type profile struct {
+		Name string `strgo:"only=lower"`
+	}
+	type form struct {
+		Owner  *profile
+		Author *profile
+	}
+	p := &profile{Name: "John"}
+	var errs strgo.FieldErrors
+	assert.True(t, errors.As(strgo.ValidateStruct(form{Owner: p, Author: p}), &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "Owner.Name", errs[0].Path)
+	assert.Equal(t, "Author.Name", errs[1].Path)
+}
+
+func TestValidateStruct_ZeroNumber(t *testing.T) {
+	type form struct {
+		Name string `strgo:"max=0"`
+	}
+	assert.EqualError(t, strgo.ValidateStruct(form{}), "the tag of the field: form.Name, is invalid: the option: max, must be a positive number")
+}